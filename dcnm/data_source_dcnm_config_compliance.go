@@ -0,0 +1,210 @@
+package dcnm
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/container"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceDCNMConfigCompliance() *schema.Resource {
+	return &schema.Resource{
+		Read: datasourceDCNMConfigComplianceRead,
+
+		Schema: map[string]*schema.Schema{
+			"fabric_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"serial_numbers": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"in_sync": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"switches": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"serial_number": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"switch_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"ip": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"pending_config": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"expected_config": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"running_config": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"pending_lines": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"line": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+
+									"policy_ids": &schema.Schema{
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// previewConfig returns a config attribute of the config preview as plain
+// text, NDFC returns it either as a string or as a list of lines.
+func previewConfig(cont *container.Container, key string) string {
+	switch config := cont.S(key).Data().(type) {
+	case string:
+		return config
+	case []interface{}:
+		return strings.Join(interfaceToStrList(config), "\n")
+	}
+	return ""
+}
+
+// getPendingLines maps every pending config line to the policies of the
+// switch whose generated config contains that line.
+func getPendingLines(dcnmClient *client.Client, serialNum, pendingConfig string) ([]interface{}, error) {
+	pendingLines := make([]interface{}, 0)
+	if strings.TrimSpace(pendingConfig) == "" {
+		return pendingLines, nil
+	}
+
+	cont, err := dcnmClient.GetviaURL(fmt.Sprintf(policyURLs["SwitchPolicies"], serialNum))
+	if err != nil {
+		return nil, err
+	}
+
+	policyLines := make(map[string][]string)
+	for _, policyCont := range cont.Children() {
+		policyID := models.G(policyCont, "policyId")
+		generatedConfig, _ := policyCont.S("generatedConfig").Data().(string)
+		for _, line := range strings.Split(generatedConfig, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				policyLines[line] = append(policyLines[line], policyID)
+			}
+		}
+	}
+
+	for _, line := range strings.Split(pendingConfig, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		policyIDs := policyLines[line]
+		sort.Strings(policyIDs)
+		pendingLines = append(pendingLines, map[string]interface{}{
+			"line":       line,
+			"policy_ids": policyIDs,
+		})
+	}
+	return pendingLines, nil
+}
+
+func datasourceDCNMConfigComplianceRead(d *schema.ResourceData, m interface{}) error {
+	log.Println("[DEBUG] Begining Read method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+	fabricName := d.Get("fabric_name").(string)
+	serials := d.Get("serial_numbers").([]interface{})
+
+	durl := fmt.Sprintf("rest/control/fabrics/%s/config-preview?showBrief=false", fabricName)
+	cont, err := dcnmClient.GetviaURL(durl)
+	if err != nil {
+		return err
+	}
+
+	inSync := true
+	switches := make([]interface{}, 0)
+	for _, switchCont := range cont.Children() {
+		serialNum := models.G(switchCont, "switchId")
+		if len(serials) > 0 && !contains(serials, serialNum) {
+			continue
+		}
+
+		status := models.G(switchCont, "status")
+		if status != "In-Sync" {
+			inSync = false
+		}
+
+		pendingConfig := previewConfig(switchCont, "pendingConfig")
+		pendingLines, err := getPendingLines(dcnmClient, serialNum, pendingConfig)
+		if err != nil {
+			return err
+		}
+
+		switches = append(switches, map[string]interface{}{
+			"serial_number":   serialNum,
+			"switch_name":     models.G(switchCont, "switchName"),
+			"ip":              models.G(switchCont, "ipAddress"),
+			"status":          status,
+			"pending_config":  pendingConfig,
+			"expected_config": previewConfig(switchCont, "generatedConfig"),
+			"running_config":  previewConfig(switchCont, "runningConfig"),
+			"pending_lines":   pendingLines,
+		})
+	}
+
+	sort.Slice(switches, func(i, j int) bool {
+		return switches[i].(map[string]interface{})["serial_number"].(string) < switches[j].(map[string]interface{})["serial_number"].(string)
+	})
+	d.Set("switches", switches)
+	d.Set("in_sync", inSync)
+
+	d.SetId(fabricName)
+	log.Println("[DEBUG] End of Read method ", d.Id())
+	return nil
+}
@@ -0,0 +1,132 @@
+package dcnm
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var previewURLs = map[string]string{
+	"VRF":     "/rest/top-down/fabrics/%s/vrfs/preview?vrf-names=%s",
+	"Network": "/rest/top-down/fabrics/%s/networks/preview?network-names=%s",
+}
+
+func datasourceDCNMDeploymentPreview() *schema.Resource {
+	return &schema.Resource{
+		Read: datasourceDCNMDeploymentPreviewRead,
+
+		Schema: map[string]*schema.Schema{
+			"fabric_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"vrf_name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"vrf_name", "network_name"},
+			},
+
+			"network_name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"vrf_name", "network_name"},
+			},
+
+			"serial_numbers": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"switches": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"serial_number": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"switch_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"config": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceDCNMDeploymentPreviewRead(d *schema.ResourceData, m interface{}) error {
+	log.Println("[DEBUG] Begining Read method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+	fabricName := d.Get("fabric_name").(string)
+	serials := interfaceToStrList(d.Get("serial_numbers"))
+
+	var durl, name string
+	if vrfName, ok := d.GetOk("vrf_name"); ok {
+		name = vrfName.(string)
+		durl = fmt.Sprintf(previewURLs["VRF"], fabricName, name)
+	} else {
+		name = d.Get("network_name").(string)
+		durl = fmt.Sprintf(previewURLs["Network"], fabricName, name)
+	}
+	if len(serials) > 0 {
+		durl = fmt.Sprintf("%s&serial-numbers=%s", durl, strings.Join(serials, ","))
+	}
+
+	cont, err := dcnmClient.GetviaURL(durl)
+	if err != nil {
+		return err
+	}
+
+	switches := make([]interface{}, 0)
+	for _, switchCont := range cont.Children() {
+		serialNum := models.G(switchCont, "serialNumber")
+		if serialNum == "" {
+			serialNum = models.G(switchCont, "switchId")
+		}
+
+		config := previewConfig(switchCont, "generatedConfig")
+		if config == "" {
+			config = previewConfig(switchCont, "config")
+		}
+
+		switches = append(switches, map[string]interface{}{
+			"serial_number": serialNum,
+			"switch_name":   models.G(switchCont, "switchName"),
+			"status":        models.G(switchCont, "status"),
+			"config":        config,
+		})
+	}
+
+	sort.Slice(switches, func(i, j int) bool {
+		return switches[i].(map[string]interface{})["serial_number"].(string) < switches[j].(map[string]interface{})["serial_number"].(string)
+	})
+	d.Set("switches", switches)
+
+	d.SetId(fmt.Sprintf("%s:%s", fabricName, name))
+	log.Println("[DEBUG] End of Read method ", d.Id())
+	return nil
+}
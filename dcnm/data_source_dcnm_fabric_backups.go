@@ -0,0 +1,152 @@
+package dcnm
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceDCNMFabricBackups() *schema.Resource {
+	return &schema.Resource{
+		Read: datasourceDCNMFabricBackupsRead,
+
+		Schema: map[string]*schema.Schema{
+			"fabric_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"serial_number": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"backup_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"running_config": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"backups": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"backup_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"tag": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"timestamp": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceDCNMFabricBackupsRead(d *schema.ResourceData, m interface{}) error {
+	log.Println("[DEBUG] Begining Read method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+	fabricName := d.Get("fabric_name").(string)
+	serialNum := d.Get("serial_number").(string)
+
+	backupConts, err := getBackups(dcnmClient, fabricName, serialNum)
+	if err != nil {
+		return err
+	}
+
+	backups := make([]interface{}, 0, len(backupConts))
+	latestID, latestTime := "", time.Time{}
+	for _, cont := range backupConts {
+		backupID := models.G(cont, "backupId")
+		timestamp := models.G(cont, "backupDate")
+		backupTime, err := parseBackupDate(timestamp)
+		if err != nil {
+			log.Printf("[WARN] backup %s has an unknown date %s: %s", backupID, timestamp, err)
+		}
+		backups = append(backups, map[string]interface{}{
+			"backup_id": backupID,
+			"tag":       models.G(cont, "tag"),
+			"timestamp": timestamp,
+			"status":    models.G(cont, "status"),
+		})
+		if latestID == "" || backupTime.After(latestTime) {
+			latestID, latestTime = backupID, backupTime
+		}
+	}
+	d.Set("backups", backups)
+
+	if serialNum != "" {
+		backupID := d.Get("backup_id").(string)
+		if backupID == "" {
+			backupID = latestID
+		}
+		if backupID == "" {
+			return fmt.Errorf("no config archive found for switch %s", serialNum)
+		}
+
+		cont, err := dcnmClient.GetviaURL(fmt.Sprintf(backupURLs["ArchivedConfig"], serialNum, backupID))
+		if err != nil {
+			return err
+		}
+		// read the raw value, models.G would keep the JSON escaping of newlines
+		runningConfig, _ := cont.S("config").Data().(string)
+		d.Set("backup_id", backupID)
+		d.Set("running_config", runningConfig)
+	}
+
+	if serialNum != "" {
+		d.SetId(fmt.Sprintf("%s:%s", fabricName, serialNum))
+	} else {
+		d.SetId(fabricName)
+	}
+	log.Println("[DEBUG] End of Read method ", d.Id())
+	return nil
+}
+
+// backupDateLayouts holds the formats the controllers report backup dates in.
+var backupDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006/01/02 15:04:05",
+}
+
+// parseBackupDate parses the date of a config archive, given either as a
+// formatted date or as epoch milliseconds.
+func parseBackupDate(date string) (time.Time, error) {
+	if millis, err := strconv.ParseInt(date, 10, 64); err == nil {
+		return time.Unix(0, millis*int64(time.Millisecond)).UTC(), nil
+	}
+	for _, layout := range backupDateLayouts {
+		if t, err := time.Parse(layout, date); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported date format")
+}
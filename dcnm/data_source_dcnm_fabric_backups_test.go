@@ -0,0 +1,45 @@
+package dcnm
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseBackupDate(t *testing.T) {
+	expected := time.Date(2021, time.June, 9, 8, 5, 3, 0, time.UTC)
+
+	for _, date := range []string{
+		"2021-06-09T08:05:03Z",
+		"2021-06-09 08:05:03",
+		"2021-06-09 08:05:03.0",
+		"2021-06-09T08:05:03",
+		"2021/06/09 08:05:03",
+		"1623225903000",
+	} {
+		got, err := parseBackupDate(date)
+		if err != nil {
+			t.Errorf("%s: %s", date, err)
+			continue
+		}
+		if !got.Equal(expected) {
+			t.Errorf("%s: expected %s, got %s", date, expected, got)
+		}
+	}
+
+	// string comparison would pick the single digit hour as the latest backup
+	early, err := parseBackupDate("2021-06-09 9:05:03")
+	if err != nil {
+		t.Fatal(err)
+	}
+	late, err := parseBackupDate("2021-06-09 10:05:03")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !late.After(early) {
+		t.Errorf("expected %s after %s", late, early)
+	}
+
+	if _, err := parseBackupDate("yesterday"); err == nil {
+		t.Errorf("expected an error for an unknown date format")
+	}
+}
@@ -0,0 +1,286 @@
+package dcnm
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/container"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var interfaceTypes = map[string]string{
+	"ethernet":      "INTERFACE_ETHERNET",
+	"port-channel":  "INTERFACE_PORT_CHANNEL",
+	"vpc":           "INTERFACE_VPC",
+	"loopback":      "INTERFACE_LOOPBACK",
+	"sub-interface": "SUBINTERFACE",
+	"vlan":          "INTERFACE_VLAN",
+}
+
+func datasourceDCNMInterfaces() *schema.Resource {
+	return &schema.Resource{
+		Read: datasourceDCNMInterfacesRead,
+
+		Schema: map[string]*schema.Schema{
+			"serial_numbers": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"ethernet",
+					"port-channel",
+					"vpc",
+					"loopback",
+					"sub-interface",
+					"vlan",
+				}, false),
+			},
+
+			"policy": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"admin_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"up",
+					"down",
+				}, false),
+			},
+
+			"oper_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"up",
+					"down",
+				}, false),
+			},
+
+			"description_regex": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+
+			"port_channel": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"interfaces": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"serial_number": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"switch_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"policy": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"admin_status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"oper_status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"description": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"speed": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"mtu": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"mode": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"allowed_vlans": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"native_vlan": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"port_channel": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"neighbor_switch": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"neighbor_interface": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// interfaceDetail returns an attribute of the interface detail, with the
+// null values of the API mapped to an empty string.
+func interfaceDetail(cont *container.Container, key string) string {
+	if value := models.G(cont, key); value != "null" && value != "{}" {
+		return value
+	}
+	return ""
+}
+
+// portChannelID strips the port-channel prefix, so "port-channel10",
+// "Port-channel10" and "10" compare equal.
+func portChannelID(name string) string {
+	return strings.TrimPrefix(strings.ToLower(name), "port-channel")
+}
+
+func datasourceDCNMInterfacesRead(d *schema.ResourceData, m interface{}) error {
+	log.Println("[DEBUG] Begining Read method ")
+
+	dcnmClient := m.(*client.Client)
+	serials := interfaceToStrList(d.Get("serial_numbers"))
+
+	var descrRegex *regexp.Regexp
+	if expr, ok := d.GetOk("description_regex"); ok {
+		descrRegex = regexp.MustCompile(expr.(string))
+	}
+
+	interfaceTypeNames := make(map[string]string)
+	for name, ifType := range interfaceTypes {
+		interfaceTypeNames[ifType] = name
+	}
+
+	interfaces := make([]map[string]interface{}, 0)
+	for _, serialNum := range serials {
+		cont, err := dcnmClient.GetviaURL(fmt.Sprintf("/rest/interface/detail?serialNumber=%s", serialNum))
+		if err != nil {
+			return err
+		}
+
+		for _, intfCont := range cont.Children() {
+			ifType := interfaceTypeNames[interfaceDetail(intfCont, "ifType")]
+			policy := interfaceDetail(intfCont, "policyName")
+			adminStatus := strings.ToLower(interfaceDetail(intfCont, "adminStatusStr"))
+			operStatus := strings.ToLower(interfaceDetail(intfCont, "operStatusStr"))
+			description := interfaceDetail(intfCont, "alias")
+			portChannel := interfaceDetail(intfCont, "channelIdStr")
+
+			if v, ok := d.GetOk("type"); ok && v.(string) != ifType {
+				continue
+			}
+			if v, ok := d.GetOk("policy"); ok && v.(string) != policy {
+				continue
+			}
+			if v, ok := d.GetOk("admin_status"); ok && v.(string) != adminStatus {
+				continue
+			}
+			if v, ok := d.GetOk("oper_status"); ok && v.(string) != operStatus {
+				continue
+			}
+			if descrRegex != nil && !descrRegex.MatchString(description) {
+				continue
+			}
+			if v, ok := d.GetOk("port_channel"); ok && (portChannel == "" || portChannelID(v.(string)) != portChannelID(portChannel)) {
+				continue
+			}
+
+			neighborSwitch, neighborInterface := "", ""
+			if neighbors := intfCont.S("neighbours").Children(); len(neighbors) > 0 {
+				neighborSwitch = interfaceDetail(neighbors[0], "switchName")
+				neighborInterface = interfaceDetail(neighbors[0], "interfaceName")
+			}
+
+			interfaces = append(interfaces, map[string]interface{}{
+				"serial_number":      serialNum,
+				"switch_name":        interfaceDetail(intfCont, "sysName"),
+				"name":               interfaceDetail(intfCont, "ifName"),
+				"type":               ifType,
+				"policy":             policy,
+				"admin_status":       adminStatus,
+				"oper_status":        operStatus,
+				"description":        description,
+				"speed":              interfaceDetail(intfCont, "speedStr"),
+				"mtu":                interfaceDetail(intfCont, "mtu"),
+				"mode":               interfaceDetail(intfCont, "mode"),
+				"allowed_vlans":      interfaceDetail(intfCont, "allowedVLANs"),
+				"native_vlan":        interfaceDetail(intfCont, "nativeVlanId"),
+				"port_channel":       portChannel,
+				"neighbor_switch":    neighborSwitch,
+				"neighbor_interface": neighborInterface,
+			})
+		}
+	}
+
+	sort.Slice(interfaces, func(i, j int) bool {
+		if interfaces[i]["serial_number"].(string) != interfaces[j]["serial_number"].(string) {
+			return interfaces[i]["serial_number"].(string) < interfaces[j]["serial_number"].(string)
+		}
+		return interfaces[i]["name"].(string) < interfaces[j]["name"].(string)
+	})
+
+	interfaceList := make([]interface{}, 0, len(interfaces))
+	for _, intf := range interfaces {
+		interfaceList = append(interfaceList, intf)
+	}
+	d.Set("interfaces", interfaceList)
+
+	d.SetId(strings.Join(serials, ","))
+	log.Println("[DEBUG] End of Read method ", d.Id())
+	return nil
+}
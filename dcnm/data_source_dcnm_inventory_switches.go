@@ -0,0 +1,223 @@
+package dcnm
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/container"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func datasourceDCNMInventorySwitches() *schema.Resource {
+	return &schema.Resource{
+		Read: datasourceDCNMInventorySwitchesRead,
+
+		Schema: map[string]*schema.Schema{
+			"fabric_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"role": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"model": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"name_regex": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+
+			"serial_number": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"ip": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"vpc_pair": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"switches": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"serial_number": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"switch_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"ip": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"switch_db_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"role": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"model": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"release": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"mode": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"fabric_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"vpc_peer_serial_number": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// inventorySwitchRole returns the role of an inventory entry in the format
+// used by the provider, e.g. "border_gateway".
+func inventorySwitchRole(dcnmClient *client.Client, cont *container.Container) string {
+	role := models.G(cont, "switchRole")
+	if role == "" || role == "null" {
+		var err error
+		role, err = getSwitchRole(dcnmClient, models.G(cont, "serialNumber"))
+		if err != nil {
+			log.Println("error at fetching switch role :", models.G(cont, "serialNumber"), err)
+			return ""
+		}
+	}
+	return strings.ReplaceAll(strings.Trim(role, " "), " ", "_")
+}
+
+func datasourceDCNMInventorySwitchesRead(d *schema.ResourceData, m interface{}) error {
+	log.Println("[DEBUG] Begining Read method ")
+
+	dcnmClient := m.(*client.Client)
+	fabricName := d.Get("fabric_name").(string)
+
+	cont, err := dcnmClient.GetviaURL(fmt.Sprintf("/rest/control/fabrics/%s/inventory", fabricName))
+	if err != nil {
+		return err
+	}
+
+	var nameRegex *regexp.Regexp
+	if expr, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(expr.(string))
+	}
+
+	// members of the vPC pair are the given switch and its peer
+	vpcPair := d.Get("vpc_pair").(string)
+	vpcMembers := make(map[string]bool)
+	if vpcPair != "" {
+		vpcMembers[vpcPair] = true
+		for _, switchCont := range cont.Children() {
+			if models.G(switchCont, "serialNumber") == vpcPair {
+				vpcMembers[models.G(switchCont, "peerSerialNumber")] = true
+			}
+		}
+	}
+
+	switches := make([]map[string]interface{}, 0)
+	for _, switchCont := range cont.Children() {
+		serialNum := models.G(switchCont, "serialNumber")
+		switchName := models.G(switchCont, "logicalName")
+
+		if v, ok := d.GetOk("serial_number"); ok && v.(string) != serialNum {
+			continue
+		}
+		if v, ok := d.GetOk("ip"); ok && v.(string) != models.G(switchCont, "ipAddress") {
+			continue
+		}
+		if v, ok := d.GetOk("model"); ok && v.(string) != models.G(switchCont, "model") {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(switchName) {
+			continue
+		}
+		if vpcPair != "" && !vpcMembers[serialNum] {
+			continue
+		}
+
+		role := inventorySwitchRole(dcnmClient, switchCont)
+		if v, ok := d.GetOk("role"); ok && v.(string) != role {
+			continue
+		}
+
+		vpcPeer := ""
+		if models.G(switchCont, "isVpcConfigured") == "true" {
+			vpcPeer = models.G(switchCont, "peerSerialNumber")
+		}
+
+		switches = append(switches, map[string]interface{}{
+			"serial_number":          serialNum,
+			"switch_name":            switchName,
+			"ip":                     models.G(switchCont, "ipAddress"),
+			"switch_db_id":           models.G(switchCont, "switchDbID"),
+			"role":                   role,
+			"model":                  models.G(switchCont, "model"),
+			"release":                models.G(switchCont, "release"),
+			"mode":                   models.G(switchCont, "mode"),
+			"fabric_name":            models.G(switchCont, "fabricName"),
+			"vpc_peer_serial_number": vpcPeer,
+		})
+	}
+
+	sort.Slice(switches, func(i, j int) bool {
+		if switches[i]["switch_name"].(string) != switches[j]["switch_name"].(string) {
+			return switches[i]["switch_name"].(string) < switches[j]["switch_name"].(string)
+		}
+		return switches[i]["serial_number"].(string) < switches[j]["serial_number"].(string)
+	})
+
+	switchList := make([]interface{}, 0, len(switches))
+	for _, switchInfo := range switches {
+		switchList = append(switchList, switchInfo)
+	}
+	d.Set("switches", switchList)
+
+	d.SetId(fabricName)
+	log.Println("[DEBUG] End of Read method ", d.Id())
+	return nil
+}
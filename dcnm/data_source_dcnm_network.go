@@ -95,16 +95,12 @@ func datasourceDCNMNetwork() *schema.Resource {
 				Computed: true,
 			},
 
-			"secondary_gw_1": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-			},
-
-			"secondary_gw_2": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
+			"secondary_gateways": &schema.Schema{
+				Type:     schema.TypeList,
 				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
 			},
 
 			"arp_supp_flag": &schema.Schema{
@@ -125,22 +121,22 @@ func datasourceDCNMNetwork() *schema.Resource {
 				Computed: true,
 			},
 
-			"dhcp_1": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-			},
-
-			"dhcp_2": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
+			"dhcp_relay": &schema.Schema{
+				Type:     schema.TypeList,
 				Computed: true,
-			},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"server_address": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
 
-			"dhcp_vrf": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
+						"vrf_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
 			},
 
 			"loopback_id": &schema.Schema{
@@ -0,0 +1,136 @@
+package dcnm
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceDCNMNetworks() *schema.Resource {
+	filters := topDownFilterSchema()
+	filters["vrf_name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+	filters["networks"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"network_id": &schema.Schema{
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+
+				"vrf_name": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"vlan_id": &schema.Schema{
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+
+				"ipv4_gateway": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"template": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"deploy_status": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"attachments": topDownAttachmentSchema(),
+			},
+		},
+	}
+
+	return &schema.Resource{
+		Read:   datasourceDCNMNetworksRead,
+		Schema: filters,
+	}
+}
+
+func datasourceDCNMNetworksRead(d *schema.ResourceData, m interface{}) error {
+	log.Println("[DEBUG] Begining Read method ")
+
+	dcnmClient := m.(*client.Client)
+	fabricName := d.Get("fabric_name").(string)
+
+	cont, err := dcnmClient.GetviaURL(fmt.Sprintf("/rest/top-down/fabrics/%s/networks", fabricName))
+	if err != nil {
+		return err
+	}
+
+	networks := make([]map[string]interface{}, 0)
+	names := make([]string, 0)
+	for _, networkCont := range cont.Children() {
+		name := models.G(networkCont, "networkName")
+		vrfName := models.G(networkCont, "vrf")
+		segment, _ := strconv.Atoi(models.G(networkCont, "networkId"))
+		status := models.G(networkCont, "networkStatus")
+
+		vlan := 0
+		gateway := ""
+		if configCont, err := cleanJsonString(models.G(networkCont, "networkTemplateConfig")); err == nil {
+			vlan, _ = strconv.Atoi(models.G(configCont, "vlanId"))
+			if configCont.Exists("gatewayIpAddress") {
+				gateway = models.G(configCont, "gatewayIpAddress")
+			}
+		}
+
+		if v, ok := d.GetOk("vrf_name"); ok && v.(string) != vrfName {
+			continue
+		}
+		if !matchTopDownFilters(d, name, vlan, segment, status) {
+			continue
+		}
+
+		names = append(names, name)
+		networks = append(networks, map[string]interface{}{
+			"name":          name,
+			"network_id":    segment,
+			"vrf_name":      vrfName,
+			"vlan_id":       vlan,
+			"ipv4_gateway":  gateway,
+			"template":      models.G(networkCont, "networkTemplate"),
+			"deploy_status": status,
+		})
+	}
+
+	summaries, err := getAttachmentSummaries(dcnmClient, fabricName, "network", names)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(networks, func(i, j int) bool {
+		return networks[i]["name"].(string) < networks[j]["name"].(string)
+	})
+	networkList := make([]interface{}, 0, len(networks))
+	for _, network := range networks {
+		network["attachments"] = summaries[network["name"].(string)]
+		networkList = append(networkList, network)
+	}
+	d.Set("networks", networkList)
+
+	d.SetId(fabricName)
+	log.Println("[DEBUG] End of Read method ", d.Id())
+	return nil
+}
@@ -0,0 +1,202 @@
+package dcnm
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceDCNMPolicies() *schema.Resource {
+	return &schema.Resource{
+		Read: datasourceDCNMPoliciesRead,
+
+		Schema: map[string]*schema.Schema{
+			"serial_number": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"template_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"source": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"entity_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"entity_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"priority_min": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"priority_max": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"policies": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"policy_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"serial_number": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"template_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"source": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"entity_type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"entity_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"priority": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"description": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"template_props": &schema.Schema{
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceDCNMPoliciesRead(d *schema.ResourceData, m interface{}) error {
+	log.Println("[DEBUG] Begining Read method ")
+
+	dcnmClient := m.(*client.Client)
+	serialNum := d.Get("serial_number").(string)
+
+	durl := policyURLs["List"]
+	if serialNum != "" {
+		durl = fmt.Sprintf(policyURLs["SwitchPolicies"], serialNum)
+	}
+	cont, err := dcnmClient.GetviaURL(durl)
+	if err != nil {
+		return err
+	}
+
+	filters := map[string]string{
+		"template_name": "templateName",
+		"source":        "source",
+		"entity_type":   "entityType",
+		"entity_name":   "entityName",
+	}
+
+	policies := make([]map[string]interface{}, 0)
+	for _, policyCont := range cont.Children() {
+		if models.G(policyCont, "deleted") == "true" {
+			continue
+		}
+
+		matched := true
+		for attr, key := range filters {
+			if v, ok := d.GetOk(attr); ok && v.(string) != models.G(policyCont, key) {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		priority, _ := strconv.Atoi(models.G(policyCont, "priority"))
+		if min, ok := d.GetOk("priority_min"); ok && priority < min.(int) {
+			continue
+		}
+		if max, ok := d.GetOk("priority_max"); ok && priority > max.(int) {
+			continue
+		}
+
+		props := make(map[string]interface{})
+		var nvPair map[string]interface{}
+		if err := json.Unmarshal([]byte(models.G(policyCont, "nvPairs")), &nvPair); err == nil {
+			for key, value := range nvPair {
+				props[key] = fmt.Sprintf("%v", value)
+			}
+		}
+
+		policies = append(policies, map[string]interface{}{
+			"policy_id":      models.G(policyCont, "policyId"),
+			"serial_number":  models.G(policyCont, "serialNumber"),
+			"template_name":  models.G(policyCont, "templateName"),
+			"source":         models.G(policyCont, "source"),
+			"entity_type":    models.G(policyCont, "entityType"),
+			"entity_name":    models.G(policyCont, "entityName"),
+			"priority":       priority,
+			"description":    models.G(policyCont, "description"),
+			"template_props": props,
+		})
+	}
+
+	sort.Slice(policies, func(i, j int) bool {
+		if policies[i]["serial_number"].(string) != policies[j]["serial_number"].(string) {
+			return policies[i]["serial_number"].(string) < policies[j]["serial_number"].(string)
+		}
+		return policies[i]["policy_id"].(string) < policies[j]["policy_id"].(string)
+	})
+
+	policyList := make([]interface{}, 0, len(policies))
+	for _, policy := range policies {
+		policyList = append(policyList, policy)
+	}
+	d.Set("policies", policyList)
+
+	if serialNum != "" {
+		d.SetId(serialNum)
+	} else {
+		d.SetId("policies")
+	}
+	log.Println("[DEBUG] End of Read method ", d.Id())
+	return nil
+}
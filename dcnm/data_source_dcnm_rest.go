@@ -0,0 +1,115 @@
+package dcnm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func datasourceDCNMRest() *schema.Resource {
+	return &schema.Resource{
+		Read: datasourceDCNMRestRead,
+
+		Schema: map[string]*schema.Schema{
+			"path": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"method": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "GET",
+				ValidateFunc: validation.StringInSlice([]string{
+					"GET",
+					"POST",
+				}, false),
+			},
+
+			"payload": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsJSON,
+			},
+
+			"json_paths": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"ignore_errors": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"response": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status_code": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"values": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func datasourceDCNMRestRead(d *schema.ResourceData, m interface{}) error {
+	log.Println("[DEBUG] Begining Read method ")
+
+	dcnmClient := m.(*client.Client)
+	path := d.Get("path").(string)
+	method := d.Get("method").(string)
+
+	cont, resp, err := doRest(dcnmClient, path, method, d.Get("payload").(string))
+	if resp == nil {
+		return err
+	}
+	if err == nil {
+		err = checkerrorsRest(cont, resp)
+	}
+	if err != nil && !d.Get("ignore_errors").(bool) {
+		return err
+	}
+
+	response := ""
+	if cont != nil {
+		response = cont.String()
+	}
+
+	values := make(map[string]interface{})
+	for name, jsonPath := range d.Get("json_paths").(map[string]interface{}) {
+		value, ok := jsonPathValue(cont, jsonPath.(string))
+		if !ok {
+			if err == nil {
+				return fmt.Errorf("json path %s of %s not found in the response", jsonPath, name)
+			}
+			continue
+		}
+		values[name] = value
+	}
+
+	d.Set("response", response)
+	d.Set("status_code", resp.StatusCode)
+	d.Set("values", values)
+
+	d.SetId(fmt.Sprintf("%s:%s", method, path))
+	log.Println("[DEBUG] End of Read method ", d.Id())
+	return nil
+}
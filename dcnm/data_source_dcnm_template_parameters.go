@@ -0,0 +1,309 @@
+package dcnm
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// templateParameter is a variable declared in the ##template variables
+// section of a template.
+type templateParameter struct {
+	Name        string
+	Type        string
+	Annotations map[string]string
+	Constraints map[string]string
+}
+
+var templateDeclRegex = regexp.MustCompile(`^([A-Za-z][\w\[\]]*)\s+([A-Za-z_][\w]*)\s*(\{)?\s*;?\s*$`)
+
+func datasourceDCNMTemplateParameters() *schema.Resource {
+	return &schema.Resource{
+		Read: datasourceDCNMTemplateParametersRead,
+
+		Schema: map[string]*schema.Schema{
+			"template_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"parameters": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"mandatory": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"default": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"display_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"description": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"constraints": &schema.Schema{
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+
+						"annotations": &schema.Schema{
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// parseTemplateAnnotations parses the body of an @(...) annotation, e.g.
+// `IsMandatory=true, DisplayName="NTP Server, primary"`. Quoted values may
+// contain commas and escaped quotes.
+func parseTemplateAnnotations(body string) map[string]string {
+	annotations := make(map[string]string)
+
+	var key, value strings.Builder
+	inValue, inQuotes, escaped := false, false, false
+	flush := func() {
+		if k := strings.TrimSpace(key.String()); k != "" {
+			annotations[k] = strings.TrimSpace(value.String())
+		}
+		key.Reset()
+		value.Reset()
+		inValue = false
+	}
+
+	for _, r := range body {
+		switch {
+		case escaped:
+			value.WriteRune(r)
+			escaped = false
+		case inQuotes && r == '\\':
+			escaped = true
+		case r == '"':
+			inQuotes = !inQuotes
+		case inQuotes:
+			value.WriteRune(r)
+		case r == '=' && !inValue:
+			inValue = true
+		case r == ',':
+			flush()
+		case inValue:
+			value.WriteRune(r)
+		default:
+			key.WriteRune(r)
+		}
+	}
+	flush()
+
+	return annotations
+}
+
+// annotationEnd returns the index of the parenthesis closing an annotation in
+// line, or -1 when the annotation continues on the next line. inQuotes carries
+// the quoting state across lines.
+func annotationEnd(line string, inQuotes *bool) int {
+	escaped := false
+	for i, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			*inQuotes = !*inQuotes
+		case r == ')' && !*inQuotes:
+			return i
+		}
+	}
+	return -1
+}
+
+// templateVariablesSection returns the lines of the ##template variables
+// section of a template content.
+func templateVariablesSection(content string) ([]string, error) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+
+	start := strings.Index(content, "##template variables")
+	if start < 0 {
+		return nil, fmt.Errorf("template has no ##template variables section")
+	}
+	section := content[start+len("##template variables"):]
+	if end := strings.Index(section, "\n##"); end >= 0 {
+		section = section[:end]
+	}
+	return strings.Split(section, "\n"), nil
+}
+
+// parseTemplateParameters parses the variables declared in the ##template
+// variables section of a template, together with their annotations and the
+// constraints of their declaration block.
+func parseTemplateParameters(content string) ([]templateParameter, error) {
+	lines, err := templateVariablesSection(content)
+	if err != nil {
+		return nil, err
+	}
+
+	params := make([]templateParameter, 0)
+	var annotation strings.Builder
+	inAnnotation, annotationQuotes := false, false
+	var current *templateParameter
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || (strings.HasPrefix(line, "#") && !inAnnotation) {
+			continue
+		}
+
+		// constraints block of the previous declaration
+		if current != nil {
+			if strings.HasPrefix(line, "}") {
+				params = append(params, *current)
+				current = nil
+				continue
+			}
+			for _, constraint := range strings.Split(line, ";") {
+				kv := strings.SplitN(constraint, "=", 2)
+				if len(kv) == 2 {
+					current.Constraints[strings.TrimSpace(kv[0])] = strings.Trim(strings.TrimSpace(kv[1]), `"`)
+				}
+			}
+			continue
+		}
+
+		if strings.HasPrefix(line, "@(") {
+			inAnnotation, annotationQuotes = true, false
+			annotation.Reset()
+			line = strings.TrimPrefix(line, "@(")
+		}
+		if inAnnotation {
+			if end := annotationEnd(line, &annotationQuotes); end >= 0 {
+				annotation.WriteString(line[:end])
+				inAnnotation = false
+			} else {
+				annotation.WriteString(line)
+				annotation.WriteString(" ")
+			}
+			continue
+		}
+
+		match := templateDeclRegex.FindStringSubmatch(line)
+		if match == nil {
+			log.Printf("[DEBUG] skipping template variables line %q", line)
+			annotation.Reset()
+			continue
+		}
+
+		param := templateParameter{
+			Name:        match[2],
+			Type:        match[1],
+			Annotations: parseTemplateAnnotations(annotation.String()),
+			Constraints: make(map[string]string),
+		}
+		annotation.Reset()
+
+		if match[3] == "{" {
+			current = &param
+		} else {
+			params = append(params, param)
+		}
+	}
+
+	if current != nil {
+		return nil, fmt.Errorf("unterminated declaration block of template variable %s", current.Name)
+	}
+	return params, nil
+}
+
+// templateHasParameter returns whether a template content declares the
+// variable name.
+func templateHasParameter(content, name string) (bool, error) {
+	params, err := parseTemplateParameters(content)
+	if err != nil {
+		return false, err
+	}
+	for _, param := range params {
+		if param.Name == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (param templateParameter) defaultValue() string {
+	for _, key := range []string{"Default", "DefaultValue"} {
+		if value, ok := param.Annotations[key]; ok {
+			return value
+		}
+	}
+	return param.Constraints["defaultValue"]
+}
+
+func datasourceDCNMTemplateParametersRead(d *schema.ResourceData, m interface{}) error {
+	log.Println("[DEBUG] Begining Read method ")
+
+	dcnmClient := m.(*client.Client)
+	name := d.Get("template_name").(string)
+
+	cont, err := getTemplate(dcnmClient, name)
+	if err != nil {
+		return getErrorFromContainer(cont, err)
+	}
+
+	content, _ := cont.S("content").Data().(string)
+	params, err := parseTemplateParameters(content)
+	if err != nil {
+		return fmt.Errorf("error at parsing template %s: %s", name, err)
+	}
+
+	paramList := make([]interface{}, 0, len(params))
+	for _, param := range params {
+		paramList = append(paramList, map[string]interface{}{
+			"name":         param.Name,
+			"type":         param.Type,
+			"mandatory":    strings.EqualFold(param.Annotations["IsMandatory"], "true"),
+			"default":      param.defaultValue(),
+			"display_name": param.Annotations["DisplayName"],
+			"description":  param.Annotations["Description"],
+			"constraints":  param.Constraints,
+			"annotations":  param.Annotations,
+		})
+	}
+	d.Set("parameters", paramList)
+
+	d.SetId(name)
+	log.Println("[DEBUG] End of Read method ", d.Id())
+	return nil
+}
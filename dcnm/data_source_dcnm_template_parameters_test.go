@@ -0,0 +1,128 @@
+package dcnm
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testTemplateContent = `##template properties
+name = ntp_server;
+description = NTP server;
+tags = custom;
+##
+##template variables
+# Copyright (c) 2021 by Cisco Systems, Inc.
+
+@(IsMandatory=true, DisplayName="NTP Server IP", Description="IP address of the NTP server, v4 only")
+ipV4Address NTP_SERVER_IP;
+
+@(IsMandatory=false, DisplayName="NTP Server VRF",
+  Default="management", Enum="default,management")
+string NTP_SERVER_VRF{
+  minLength=1;
+  maxLength=32;
+};
+
+@(IsMandatory=false, Description="Key (\"md5\") ID")
+integer KEY_ID {
+  min=1;
+  max=65535;
+  defaultValue=1;
+};
+
+boolean PREFER;
+##
+##template content
+ntp server $$NTP_SERVER_IP$$ use-vrf $$NTP_SERVER_VRF$$
+##
+`
+
+func TestParseTemplateParameters(t *testing.T) {
+	params, err := parseTemplateParameters(testTemplateContent)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []templateParameter{
+		{
+			Name: "NTP_SERVER_IP",
+			Type: "ipV4Address",
+			Annotations: map[string]string{
+				"IsMandatory": "true",
+				"DisplayName": "NTP Server IP",
+				"Description": "IP address of the NTP server, v4 only",
+			},
+			Constraints: map[string]string{},
+		},
+		{
+			Name: "NTP_SERVER_VRF",
+			Type: "string",
+			Annotations: map[string]string{
+				"IsMandatory": "false",
+				"DisplayName": "NTP Server VRF",
+				"Default":     "management",
+				"Enum":        "default,management",
+			},
+			Constraints: map[string]string{
+				"minLength": "1",
+				"maxLength": "32",
+			},
+		},
+		{
+			Name: "KEY_ID",
+			Type: "integer",
+			Annotations: map[string]string{
+				"IsMandatory": "false",
+				"Description": `Key ("md5") ID`,
+			},
+			Constraints: map[string]string{
+				"min":          "1",
+				"max":          "65535",
+				"defaultValue": "1",
+			},
+		},
+		{
+			Name:        "PREFER",
+			Type:        "boolean",
+			Annotations: map[string]string{},
+			Constraints: map[string]string{},
+		},
+	}
+
+	if !reflect.DeepEqual(params, expected) {
+		t.Fatalf("bad parameters\n got: %#v\nwant: %#v", params, expected)
+	}
+
+	if def := params[1].defaultValue(); def != "management" {
+		t.Errorf("bad default from annotation %q", def)
+	}
+	if def := params[2].defaultValue(); def != "1" {
+		t.Errorf("bad default from constraints %q", def)
+	}
+}
+
+func TestParseTemplateParametersErrors(t *testing.T) {
+	if _, err := parseTemplateParameters("##template content\nfeature ntp\n##"); err == nil {
+		t.Error("expected error for template without variables section")
+	}
+
+	if _, err := parseTemplateParameters("##template variables\ninteger VLAN {\n min=1;\n##"); err == nil {
+		t.Error("expected error for unterminated declaration block")
+	}
+}
+
+func TestTemplateHasParameter(t *testing.T) {
+	for name, expected := range map[string]bool{
+		"NTP_SERVER_IP": true,
+		"KEY_ID":        true,
+		"dhcpServers":   false,
+	} {
+		found, err := templateHasParameter(testTemplateContent, name)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if found != expected {
+			t.Errorf("%s: expected %t, got %t", name, expected, found)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package dcnm
+
+import (
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/container"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func datasourceDCNMTemplates() *schema.Resource {
+	return &schema.Resource{
+		Read: datasourceDCNMTemplatesRead,
+
+		Schema: map[string]*schema.Schema{
+			"tags": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"template_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"template_sub_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"supported_platform": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"name_regex": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+
+			"templates": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"description": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"tags": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+
+						"supported_platforms": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+
+						"template_type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"template_sub_type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"template_content_type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// templateListAttr returns a comma separated template attribute as a list,
+// the attribute is either a string or a list depending on the release.
+func templateListAttr(cont *container.Container, key string) []string {
+	if values, ok := cont.S(key).Data().([]interface{}); ok {
+		return interfaceToStrList(values)
+	}
+
+	values := make([]string, 0)
+	for _, value := range strings.Split(models.G(cont, key), ",") {
+		if value = strings.TrimSpace(value); value != "" && value != "null" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func datasourceDCNMTemplatesRead(d *schema.ResourceData, m interface{}) error {
+	log.Println("[DEBUG] Begining Read method ")
+
+	dcnmClient := m.(*client.Client)
+
+	cont, err := dcnmClient.GetviaURL(TemplateURLS[dcnmClient.GetPlatform()]["List"])
+	if err != nil {
+		return err
+	}
+
+	var nameRegex *regexp.Regexp
+	if expr, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(expr.(string))
+	}
+	filterTags := interfaceToStrList(d.Get("tags"))
+
+	templates := make([]map[string]interface{}, 0)
+	for _, templateCont := range cont.Children() {
+		name := models.G(templateCont, "name")
+		tags := templateListAttr(templateCont, "tags")
+		platforms := templateListAttr(templateCont, "supportedPlatforms")
+
+		if nameRegex != nil && !nameRegex.MatchString(name) {
+			continue
+		}
+		if v, ok := d.GetOk("template_type"); ok && v.(string) != models.G(templateCont, "templateType") {
+			continue
+		}
+		if v, ok := d.GetOk("template_sub_type"); ok && v.(string) != models.G(templateCont, "templateSubType") {
+			continue
+		}
+		if len(setDifference(filterTags, tags)) > 0 {
+			continue
+		}
+		if v, ok := d.GetOk("supported_platform"); ok {
+			platformList := make([]interface{}, 0, len(platforms))
+			for _, platform := range platforms {
+				platformList = append(platformList, platform)
+			}
+			if !contains(platformList, v.(string)) && !contains(platformList, "All") {
+				continue
+			}
+		}
+
+		templates = append(templates, map[string]interface{}{
+			"name":                  name,
+			"description":           models.G(templateCont, "description"),
+			"tags":                  tags,
+			"supported_platforms":   platforms,
+			"template_type":         models.G(templateCont, "templateType"),
+			"template_sub_type":     models.G(templateCont, "templateSubType"),
+			"template_content_type": models.G(templateCont, "contentType"),
+		})
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i]["name"].(string) < templates[j]["name"].(string)
+	})
+
+	templateList := make([]interface{}, 0, len(templates))
+	for _, template := range templates {
+		templateList = append(templateList, template)
+	}
+	d.Set("templates", templateList)
+
+	d.SetId("templates")
+	log.Println("[DEBUG] End of Read method ", d.Id())
+	return nil
+}
@@ -84,13 +84,13 @@ func datasourceDCNMVRF() *schema.Resource {
 			},
 
 			"trm_enable": &schema.Schema{
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
 				Computed: true,
 			},
 
 			"rp_external_flag": &schema.Schema{
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
 				Computed: true,
 			},
@@ -120,31 +120,31 @@ func datasourceDCNMVRF() *schema.Resource {
 			},
 
 			"ipv6_link_local_flag": &schema.Schema{
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
 				Computed: true,
 			},
 
 			"trm_bgw_msite_flag": &schema.Schema{
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
 				Computed: true,
 			},
 
 			"advertise_host_route": &schema.Schema{
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
 				Computed: true,
 			},
 
 			"advertise_default_route": &schema.Schema{
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
 				Computed: true,
 			},
 
 			"static_default_route": &schema.Schema{
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
 				Computed: true,
 			},
@@ -0,0 +1,260 @@
+package dcnm
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// topDownFilterSchema returns the filter arguments shared by the VRF and
+// network list data sources.
+func topDownFilterSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"fabric_name": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+		},
+
+		"name_prefix": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+
+		"name_regex": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsValidRegExp,
+		},
+
+		"vlan_id_min": &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+
+		"vlan_id_max": &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+
+		"segment_id_min": &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+
+		"segment_id_max": &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+
+		"deploy_status": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+	}
+}
+
+func topDownAttachmentSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"serial_number": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"switch_name": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"vlan_id": &schema.Schema{
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+
+				"attached": &schema.Schema{
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+
+				"status": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func datasourceDCNMVRFs() *schema.Resource {
+	filters := topDownFilterSchema()
+	filters["vrfs"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"segment_id": &schema.Schema{
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+
+				"vlan_id": &schema.Schema{
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+
+				"template": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"deploy_status": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"attachments": topDownAttachmentSchema(),
+			},
+		},
+	}
+
+	return &schema.Resource{
+		Read:   datasourceDCNMVRFsRead,
+		Schema: filters,
+	}
+}
+
+// matchTopDownFilters checks a VRF or network against the filter arguments of
+// the data source.
+func matchTopDownFilters(d *schema.ResourceData, name string, vlan, segment int, status string) bool {
+	if prefix, ok := d.GetOk("name_prefix"); ok && !strings.HasPrefix(name, prefix.(string)) {
+		return false
+	}
+	if expr, ok := d.GetOk("name_regex"); ok && !regexp.MustCompile(expr.(string)).MatchString(name) {
+		return false
+	}
+	if min, ok := d.GetOk("vlan_id_min"); ok && vlan < min.(int) {
+		return false
+	}
+	if max, ok := d.GetOk("vlan_id_max"); ok && vlan > max.(int) {
+		return false
+	}
+	if min, ok := d.GetOk("segment_id_min"); ok && segment < min.(int) {
+		return false
+	}
+	if max, ok := d.GetOk("segment_id_max"); ok && segment > max.(int) {
+		return false
+	}
+	if deployStatus, ok := d.GetOk("deploy_status"); ok && !strings.EqualFold(deployStatus.(string), status) {
+		return false
+	}
+	return true
+}
+
+// getAttachmentSummaries fetches the attachments of the given VRFs or
+// networks in one call, objectType is either "vrf" or "network".
+func getAttachmentSummaries(dcnmClient *client.Client, fabricName, objectType string, names []string) (map[string][]interface{}, error) {
+	summaries := make(map[string][]interface{})
+	if len(names) == 0 {
+		return summaries, nil
+	}
+
+	durl := fmt.Sprintf("/rest/top-down/fabrics/%s/%ss/attachments?%s-names=%s", fabricName, objectType, objectType, strings.Join(names, ","))
+	cont, err := dcnmClient.GetviaURL(durl)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, objCont := range cont.Children() {
+		name := models.G(objCont, fmt.Sprintf("%sName", objectType))
+
+		attachments := make([]interface{}, 0)
+		for _, attachCont := range objCont.S("lanAttachList").Children() {
+			vlan, _ := strconv.Atoi(models.G(attachCont, "vlanId"))
+			attachments = append(attachments, map[string]interface{}{
+				"serial_number": models.G(attachCont, "switchSerialNo"),
+				"switch_name":   models.G(attachCont, "switchName"),
+				"vlan_id":       vlan,
+				"attached":      models.G(attachCont, "isLanAttached") == "true",
+				"status":        models.G(attachCont, "lanAttachState"),
+			})
+		}
+		sort.Slice(attachments, func(i, j int) bool {
+			return attachments[i].(map[string]interface{})["serial_number"].(string) < attachments[j].(map[string]interface{})["serial_number"].(string)
+		})
+		summaries[name] = attachments
+	}
+	return summaries, nil
+}
+
+func datasourceDCNMVRFsRead(d *schema.ResourceData, m interface{}) error {
+	log.Println("[DEBUG] Begining Read method ")
+
+	dcnmClient := m.(*client.Client)
+	fabricName := d.Get("fabric_name").(string)
+
+	cont, err := dcnmClient.GetviaURL(fmt.Sprintf("/rest/top-down/fabrics/%s/vrfs", fabricName))
+	if err != nil {
+		return err
+	}
+
+	vrfs := make([]map[string]interface{}, 0)
+	names := make([]string, 0)
+	for _, vrfCont := range cont.Children() {
+		name := models.G(vrfCont, "vrfName")
+		segment, _ := strconv.Atoi(models.G(vrfCont, "vrfId"))
+		status := models.G(vrfCont, "vrfStatus")
+
+		vlan := 0
+		if configCont, err := cleanJsonString(models.G(vrfCont, "vrfTemplateConfig")); err == nil {
+			vlan, _ = strconv.Atoi(models.G(configCont, "vrfVlanId"))
+		}
+
+		if !matchTopDownFilters(d, name, vlan, segment, status) {
+			continue
+		}
+
+		names = append(names, name)
+		vrfs = append(vrfs, map[string]interface{}{
+			"name":          name,
+			"segment_id":    segment,
+			"vlan_id":       vlan,
+			"template":      models.G(vrfCont, "vrfTemplate"),
+			"deploy_status": status,
+		})
+	}
+
+	summaries, err := getAttachmentSummaries(dcnmClient, fabricName, "vrf", names)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(vrfs, func(i, j int) bool {
+		return vrfs[i]["name"].(string) < vrfs[j]["name"].(string)
+	})
+	vrfList := make([]interface{}, 0, len(vrfs))
+	for _, vrf := range vrfs {
+		vrf["attachments"] = summaries[vrf["name"].(string)]
+		vrfList = append(vrfList, vrf)
+	}
+	d.Set("vrfs", vrfList)
+
+	d.SetId(fabricName)
+	log.Println("[DEBUG] End of Read method ", d.Id())
+	return nil
+}
@@ -65,28 +65,50 @@ func Provider() *schema.Provider {
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"dcnm_vrf":            resourceDCNMVRF(),
-			"dcnm_inventory":      resourceDCNMInventory(),
-			"dcnm_network":        resourceDCNMNetwork(),
-			"dcnm_interface":      resourceDCNMInterface(),
-			"dcnm_rest":           resourceDCNMRest(),
-			"dcnm_policy":         resourceDCNMPolicy(),
-			"dcnm_service_node":   resourceDCNMServiceNode(),
-			"dcnm_route_peering":  resourceRoutePeering(),
-			"dcnm_service_policy": resourceDCNMServicePolicy(),
-			"dcnm_template":       resourceDCNMTemplate(),
+			"dcnm_vrf":                     resourceDCNMVRF(),
+			"dcnm_inventory":               resourceDCNMInventory(),
+			"dcnm_network":                 resourceDCNMNetwork(),
+			"dcnm_interface":               resourceDCNMInterface(),
+			"dcnm_rest":                    resourceDCNMRest(),
+			"dcnm_policy":                  resourceDCNMPolicy(),
+			"dcnm_service_node":            resourceDCNMServiceNode(),
+			"dcnm_route_peering":           resourceRoutePeering(),
+			"dcnm_service_policy":          resourceDCNMServicePolicy(),
+			"dcnm_template":                resourceDCNMTemplate(),
+			"dcnm_switch_credentials":      resourceDCNMSwitchCredentials(),
+			"dcnm_inventory_poap":          resourceDCNMInventoryPOAP(),
+			"dcnm_switch_rma":              resourceDCNMSwitchRMA(),
+			"dcnm_switch":                  resourceDCNMSwitch(),
+			"dcnm_image_policy":            resourceDCNMImagePolicy(),
+			"dcnm_image_policy_attachment": resourceDCNMImagePolicyAttachment(),
+			"dcnm_switch_upgrade":          resourceDCNMSwitchUpgrade(),
+			"dcnm_user":                    resourceDCNMUser(),
+			"dcnm_user_role":               resourceDCNMUserRole(),
+			"dcnm_security_domain":         resourceDCNMSecurityDomain(),
+			"dcnm_fabric_backup":           resourceDCNMFabricBackup(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"dcnm_vrf":            datasourceDCNMVRF(),
-			"dcnm_inventory":      datasourceDCNMInventory(),
-			"dcnm_network":        datasourceDCNMNetwork(),
-			"dcnm_interface":      datasourceDCNMInterface(),
-			"dcnm_policy":         datasourceDCNMPolicy(),
-			"dcnm_service_node":   datasourceDCNMServiceNode(),
-			"dcnm_route_peering":  datasourceDCNMRoutePeering(),
-			"dcnm_service_policy": datasourceDCNMServicePolicy(),
-			"dcnm_template":       datasourceDCNMTemplate(),
+			"dcnm_vrf":                 datasourceDCNMVRF(),
+			"dcnm_inventory":           datasourceDCNMInventory(),
+			"dcnm_network":             datasourceDCNMNetwork(),
+			"dcnm_interface":           datasourceDCNMInterface(),
+			"dcnm_policy":              datasourceDCNMPolicy(),
+			"dcnm_service_node":        datasourceDCNMServiceNode(),
+			"dcnm_route_peering":       datasourceDCNMRoutePeering(),
+			"dcnm_service_policy":      datasourceDCNMServicePolicy(),
+			"dcnm_template":            datasourceDCNMTemplate(),
+			"dcnm_fabric_backups":      datasourceDCNMFabricBackups(),
+			"dcnm_config_compliance":   datasourceDCNMConfigCompliance(),
+			"dcnm_deployment_preview":  datasourceDCNMDeploymentPreview(),
+			"dcnm_inventory_switches":  datasourceDCNMInventorySwitches(),
+			"dcnm_vrfs":                datasourceDCNMVRFs(),
+			"dcnm_networks":            datasourceDCNMNetworks(),
+			"dcnm_interfaces":          datasourceDCNMInterfaces(),
+			"dcnm_policies":            datasourceDCNMPolicies(),
+			"dcnm_templates":           datasourceDCNMTemplates(),
+			"dcnm_template_parameters": datasourceDCNMTemplateParameters(),
+			"dcnm_rest":                datasourceDCNMRest(),
 		},
 		ConfigureFunc: configClient,
 	}
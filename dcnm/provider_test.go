@@ -1,11 +1,13 @@
 package dcnm
 
 import (
+	"context"
 	"os"
 	"sync"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 var testAccProviders map[string]*schema.Provider
@@ -48,6 +50,20 @@ func testAccProviderFactoriesInternal(provider **schema.Provider) map[string]fun
 	return testAccProviderFactoriesInit(provider, "dcnm")
 }
 
+// testAccCreateUnmanaged creates an object through the create function of the
+// resource without adding it to the state, so a test can adopt it.
+func testAccCreateUnmanaged(t *testing.T, provider *schema.Provider, r *schema.Resource, raw map[string]interface{}) {
+	if provider.Meta() == nil {
+		if diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(nil)); diags.HasError() {
+			t.Fatalf("configuring the provider failed: %v", diags)
+		}
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if diags := r.CreateContext(context.Background(), d, provider.Meta()); diags.HasError() {
+		t.Fatalf("creating the unmanaged object failed: %v", diags)
+	}
+}
+
 func TestProvider(t *testing.T) {
 	if err := Provider().InternalValidate(); err != nil {
 		t.Fatalf("err : %s", err)
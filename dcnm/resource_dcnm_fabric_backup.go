@@ -0,0 +1,217 @@
+package dcnm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/container"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var backupURLs = map[string]string{
+	"FabricBackup":   "/rest/control/fabrics/%s/backup",
+	"FabricBackups":  "/rest/control/fabrics/%s/backups",
+	"SwitchArchive":  "/rest/config/archive/devices/%s/backup",
+	"SwitchArchives": "/rest/config/archive/devices/%s/archives",
+	"ArchivedConfig": "/rest/config/archive/devices/%s/archives/%s",
+}
+
+func resourceDCNMFabricBackup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDCNMFabricBackupCreate,
+		ReadContext:   resourceDCNMFabricBackupRead,
+		DeleteContext: resourceDCNMFabricBackupDelete,
+
+		Schema: map[string]*schema.Schema{
+			"fabric_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"serial_number": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"tag": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"backup_timeout": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Default:  10,
+			},
+
+			"backup_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"timestamp": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// getBackups lists the fabric backups, or the config archives of a single
+// switch when a serial number is given.
+func getBackups(dcnmClient *client.Client, fabricName, serialNum string) ([]*container.Container, error) {
+	durl := fmt.Sprintf(backupURLs["FabricBackups"], fabricName)
+	if serialNum != "" {
+		durl = fmt.Sprintf(backupURLs["SwitchArchives"], serialNum)
+	}
+
+	cont, err := dcnmClient.GetviaURL(durl)
+	if err != nil {
+		return nil, err
+	}
+	return cont.Children(), nil
+}
+
+func getBackup(dcnmClient *client.Client, fabricName, serialNum, backupID string) (*container.Container, error) {
+	backupConts, err := getBackups(dcnmClient, fabricName, serialNum)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cont := range backupConts {
+		if models.G(cont, "backupId") == backupID {
+			return cont, nil
+		}
+	}
+	return nil, fmt.Errorf("backup %s not found", backupID)
+}
+
+// waitForBackup waits until a backup which is not part of knownIDs shows up
+// and is completed.
+func waitForBackup(dcnmClient *client.Client, fabricName, serialNum string, knownIDs map[string]bool, timeout int) (*container.Container, error) {
+	initTime := time.Now()
+	for time.Since(initTime) < (time.Duration(timeout) * time.Second) {
+		time.Sleep(10 * time.Second)
+
+		backupConts, err := getBackups(dcnmClient, fabricName, serialNum)
+		if err != nil {
+			log.Println("Error at get call for backups :", err)
+			continue
+		}
+
+		for _, cont := range backupConts {
+			if knownIDs[models.G(cont, "backupId")] {
+				continue
+			}
+
+			status := strings.ToUpper(models.G(cont, "status"))
+			switch status {
+			case "SUCCESS", "COMPLETED":
+				return cont, nil
+			case "FAILED", "FAILURE":
+				return cont, fmt.Errorf("backup %s failed", models.G(cont, "backupId"))
+			}
+		}
+	}
+	return nil, fmt.Errorf("timeout occurs before backup of %s is completed", fabricName)
+}
+
+func setFabricBackupAttributes(d *schema.ResourceData, cont *container.Container) *schema.ResourceData {
+	d.Set("backup_id", models.G(cont, "backupId"))
+	d.Set("timestamp", models.G(cont, "backupDate"))
+	d.Set("status", models.G(cont, "status"))
+
+	d.SetId(models.G(cont, "backupId"))
+	return d
+}
+
+func resourceDCNMFabricBackupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Create method ")
+
+	dcnmClient := m.(*client.Client)
+	fabricName := d.Get("fabric_name").(string)
+	serialNum := d.Get("serial_number").(string)
+
+	backupConts, err := getBackups(dcnmClient, fabricName, serialNum)
+	if err != nil {
+		return diag.Errorf("error at fetching backups of %s: %s", fabricName, err)
+	}
+	knownIDs := make(map[string]bool)
+	for _, cont := range backupConts {
+		knownIDs[models.G(cont, "backupId")] = true
+	}
+
+	durl := fmt.Sprintf(backupURLs["FabricBackup"], fabricName)
+	if serialNum != "" {
+		durl = fmt.Sprintf(backupURLs["SwitchArchive"], serialNum)
+	}
+	payload := map[string]interface{}{
+		"tag": d.Get("tag").(string),
+	}
+	_, err = makeAndDoRequest(dcnmClient, "POST", durl, payload, false)
+	if err != nil {
+		return diag.Errorf("error at triggering backup of %s: %s", fabricName, err)
+	}
+
+	cont, err := waitForBackup(dcnmClient, fabricName, serialNum, knownIDs, d.Get("backup_timeout").(int)*60)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	setFabricBackupAttributes(d, cont)
+
+	log.Println("[DEBUG] End of Create method ", d.Id())
+	return resourceDCNMFabricBackupRead(ctx, d, m)
+}
+
+func resourceDCNMFabricBackupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Read method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+
+	cont, err := getBackup(dcnmClient, d.Get("fabric_name").(string), d.Get("serial_number").(string), d.Id())
+	if err != nil {
+		log.Printf("[DEBUG] backup %s not found: %s", d.Id(), err)
+		d.SetId("")
+		return nil
+	}
+
+	setFabricBackupAttributes(d, cont)
+
+	log.Println("[DEBUG] End of Read method ", d.Id())
+	return nil
+}
+
+func resourceDCNMFabricBackupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Delete method ", d.Id())
+
+	// Backups are kept as per the retention settings of the controller, the
+	// resource is only removed from the state.
+	d.SetId("")
+
+	log.Println("[DEBUG] End of Delete method ", d.Id())
+	return nil
+}
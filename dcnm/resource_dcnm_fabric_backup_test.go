@@ -0,0 +1,66 @@
+package dcnm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var providerfFabricBackup *schema.Provider
+
+func TestAccDCNMFabricBackup_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactoriesInternal(&providerfFabricBackup),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDCNMFabricBackupConfig_basic("1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMFabricBackupExists("dcnm_fabric_backup.test"),
+				),
+			},
+			{
+				Config: testAccCheckDCNMFabricBackupConfig_basic("2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMFabricBackupExists("dcnm_fabric_backup.test"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDCNMFabricBackupConfig_basic(change string) string {
+	return fmt.Sprintf(`
+	resource "dcnm_fabric_backup" "test" {
+		fabric_name = "fab2"
+		tag         = "acctest"
+
+		triggers = {
+			change = "%s"
+		}
+	}
+	`, change)
+}
+
+func testAccCheckDCNMFabricBackupExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+
+		if !ok {
+			return fmt.Errorf("Fabric backup %s not found", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Fabric backup dn was set")
+		}
+
+		dcnmClient := (*providerfFabricBackup).Meta().(*client.Client)
+
+		_, err := getBackup(dcnmClient, "fab2", "", rs.Primary.ID)
+		return err
+	}
+}
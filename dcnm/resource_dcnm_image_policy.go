@@ -0,0 +1,228 @@
+package dcnm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/container"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var ImageURLS = map[string]map[string]string{
+	"dcnm": {
+		"Create":   "/fm/fmrest/policymgnt/platform-policy",
+		"Common":   "/fm/fmrest/policymgnt/image-policy/%s",
+		"Edit":     "/fm/fmrest/policymgnt/edit-policy",
+		"Delete":   "/fm/fmrest/policymgnt/policy",
+		"Attach":   "/fm/fmrest/policymgnt/attach-policy",
+		"Detach":   "/fm/fmrest/policymgnt/detach-policy?serialNumber=%s",
+		"Stage":    "/fm/fmrest/stagingmanagement/stage-image",
+		"Validate": "/fm/fmrest/stagingmanagement/validate-image",
+		"Upgrade":  "/fm/fmrest/imageupgrade/upgrade-image",
+		"Status":   "/fm/fmrest/packagemgnt/issu",
+	},
+	"nd": {
+		"Create":   "/appcenter/cisco/ndfc/api/v1/imagemanagement/rest/policymgnt/platform-policy",
+		"Common":   "/appcenter/cisco/ndfc/api/v1/imagemanagement/rest/policymgnt/image-policy/%s",
+		"Edit":     "/appcenter/cisco/ndfc/api/v1/imagemanagement/rest/policymgnt/edit-policy",
+		"Delete":   "/appcenter/cisco/ndfc/api/v1/imagemanagement/rest/policymgnt/policy",
+		"Attach":   "/appcenter/cisco/ndfc/api/v1/imagemanagement/rest/policymgnt/attach-policy",
+		"Detach":   "/appcenter/cisco/ndfc/api/v1/imagemanagement/rest/policymgnt/detach-policy?serialNumber=%s",
+		"Stage":    "/appcenter/cisco/ndfc/api/v1/imagemanagement/rest/stagingmanagement/stage-image",
+		"Validate": "/appcenter/cisco/ndfc/api/v1/imagemanagement/rest/stagingmanagement/validate-image",
+		"Upgrade":  "/appcenter/cisco/ndfc/api/v1/imagemanagement/rest/imageupgrade/upgrade-image",
+		"Status":   "/appcenter/cisco/ndfc/api/v1/imagemanagement/rest/packagemgnt/issu",
+	},
+}
+
+func resourceDCNMImagePolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDCNMImagePolicyCreate,
+		UpdateContext: resourceDCNMImagePolicyUpdate,
+		ReadContext:   resourceDCNMImagePolicyRead,
+		DeleteContext: resourceDCNMImagePolicyDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: resourceDCNMImagePolicyImporter,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"platform": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"N9K",
+					"N7K",
+					"N77",
+					"N6K",
+					"N5K",
+					"N3K",
+				}, false),
+			},
+
+			"nxos_version": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"epld_image": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"packages": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func getImagePolicyPayload(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"policyName":       d.Get("name").(string),
+		"policyType":       "PLATFORM",
+		"platform":         d.Get("platform").(string),
+		"nxosVersion":      d.Get("nxos_version").(string),
+		"epldImgName":      d.Get("epld_image").(string),
+		"packageName":      strings.Join(interfaceToStrList(d.Get("packages")), ","),
+		"policyDescr":      d.Get("description").(string),
+		"platformPolicies": "",
+		"rpmimages":        "",
+		"agnostic":         false,
+	}
+}
+
+func getRemoteImagePolicy(dcnmClient *client.Client, name string) (*container.Container, error) {
+	durl := fmt.Sprintf(ImageURLS[dcnmClient.GetPlatform()]["Common"], name)
+	cont, err := makeAndDoRequest(dcnmClient, "GET", durl, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	if models.G(cont, "policyName") != name {
+		return nil, fmt.Errorf("image policy %s not found", name)
+	}
+	return cont, nil
+}
+
+func setImagePolicyAttributes(d *schema.ResourceData, cont *container.Container) *schema.ResourceData {
+	d.Set("name", models.G(cont, "policyName"))
+	d.Set("platform", models.G(cont, "platform"))
+	d.Set("nxos_version", models.G(cont, "nxosVersion"))
+	d.Set("epld_image", models.G(cont, "epldImgName"))
+	d.Set("description", models.G(cont, "policyDescr"))
+
+	packages := make([]string, 0, 1)
+	for _, pkg := range strings.Split(models.G(cont, "packageName"), ",") {
+		if pkg = strings.TrimSpace(pkg); pkg != "" {
+			packages = append(packages, pkg)
+		}
+	}
+	d.Set("packages", packages)
+
+	d.SetId(models.G(cont, "policyName"))
+	return d
+}
+
+func resourceDCNMImagePolicyImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	log.Println("[DEBUG] Begining Importer ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+
+	cont, err := getRemoteImagePolicy(dcnmClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	stateImport := setImagePolicyAttributes(d, cont)
+
+	log.Println("[DEBUG] End of Importer ", d.Id())
+	return []*schema.ResourceData{stateImport}, nil
+}
+
+func resourceDCNMImagePolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Create method ")
+
+	dcnmClient := m.(*client.Client)
+	name := d.Get("name").(string)
+
+	_, err := makeAndDoRequest(dcnmClient, "POST", ImageURLS[dcnmClient.GetPlatform()]["Create"], getImagePolicyPayload(d), true)
+	if err != nil {
+		return diag.Errorf("error at creation of image policy %s: %s", name, err)
+	}
+
+	d.SetId(name)
+	log.Println("[DEBUG] End of Create method ", d.Id())
+	return resourceDCNMImagePolicyRead(ctx, d, m)
+}
+
+func resourceDCNMImagePolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Update method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+
+	_, err := makeAndDoRequest(dcnmClient, "POST", ImageURLS[dcnmClient.GetPlatform()]["Edit"], getImagePolicyPayload(d), true)
+	if err != nil {
+		return diag.Errorf("error at update of image policy %s: %s", d.Id(), err)
+	}
+
+	log.Println("[DEBUG] End of Update method ", d.Id())
+	return resourceDCNMImagePolicyRead(ctx, d, m)
+}
+
+func resourceDCNMImagePolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Read method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+
+	cont, err := getRemoteImagePolicy(dcnmClient, d.Id())
+	if err != nil {
+		log.Printf("[DEBUG] image policy %s not found: %s", d.Id(), err)
+		d.SetId("")
+		return nil
+	}
+
+	setImagePolicyAttributes(d, cont)
+
+	log.Println("[DEBUG] End of Read method ", d.Id())
+	return nil
+}
+
+func resourceDCNMImagePolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Delete method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+
+	payload := map[string]interface{}{
+		"policyNames": []string{d.Id()},
+	}
+	_, err := makeAndDoRequest(dcnmClient, "DELETE", ImageURLS[dcnmClient.GetPlatform()]["Delete"], payload, true)
+	if err != nil {
+		return diag.Errorf("error at deletion of image policy %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	log.Println("[DEBUG] End of Delete method ", d.Id())
+	return nil
+}
@@ -0,0 +1,184 @@
+package dcnm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/container"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceDCNMImagePolicyAttachment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDCNMImagePolicyAttachmentCreate,
+		UpdateContext: resourceDCNMImagePolicyAttachmentUpdate,
+		ReadContext:   resourceDCNMImagePolicyAttachmentRead,
+		DeleteContext: resourceDCNMImagePolicyAttachmentDelete,
+
+		Schema: map[string]*schema.Schema{
+			"fabric_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"policy_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"serial_numbers": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// getImageStatus returns the image management entries of all switches known to
+// the controller, which carry the attached policy and the operation states.
+func getImageStatus(dcnmClient *client.Client) ([]*container.Container, error) {
+	cont, err := makeAndDoRequest(dcnmClient, "GET", ImageURLS[dcnmClient.GetPlatform()]["Status"], nil, true)
+	if err != nil {
+		return nil, err
+	}
+	return cont.S("lastOperDataObject").Children(), nil
+}
+
+func attachImagePolicy(dcnmClient *client.Client, fabricName, policyName string, serials []string) error {
+	policyCont, err := getRemoteImagePolicy(dcnmClient, policyName)
+	if err != nil {
+		return err
+	}
+
+	mappingList := make([]map[string]interface{}, 0, len(serials))
+	for _, serial := range serials {
+		cont, err := getRemoteSwitch(dcnmClient, fabricName, "", serial)
+		if err != nil {
+			return fmt.Errorf("switch %s is not found in fabric %s", serial, fabricName)
+		}
+		mappingList = append(mappingList, map[string]interface{}{
+			"policyName":    policyName,
+			"serialNumber":  serial,
+			"hostName":      models.G(cont, "logicalName"),
+			"ipAddr":        models.G(cont, "ipAddress"),
+			"platform":      models.G(policyCont, "platform"),
+			"bootstrapMode": false,
+		})
+	}
+
+	payload := map[string]interface{}{
+		"mappingList": mappingList,
+	}
+	_, err = makeAndDoRequest(dcnmClient, "POST", ImageURLS[dcnmClient.GetPlatform()]["Attach"], payload, true)
+	return err
+}
+
+func detachImagePolicy(dcnmClient *client.Client, serials []string) error {
+	durl := fmt.Sprintf(ImageURLS[dcnmClient.GetPlatform()]["Detach"], strings.Join(serials, ","))
+	_, err := makeAndDoRequest(dcnmClient, "DELETE", durl, nil, true)
+	return err
+}
+
+func resourceDCNMImagePolicyAttachmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Create method ")
+
+	dcnmClient := m.(*client.Client)
+	fabricName := d.Get("fabric_name").(string)
+	policyName := d.Get("policy_name").(string)
+
+	serials := interfaceToStrList(d.Get("serial_numbers").(*schema.Set).List())
+	err := attachImagePolicy(dcnmClient, fabricName, policyName, serials)
+	if err != nil {
+		return diag.Errorf("error at attaching image policy %s: %s", policyName, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", fabricName, policyName))
+	log.Println("[DEBUG] End of Create method ", d.Id())
+	return resourceDCNMImagePolicyAttachmentRead(ctx, d, m)
+}
+
+func resourceDCNMImagePolicyAttachmentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Update method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+	policyName := d.Get("policy_name").(string)
+
+	if d.HasChange("serial_numbers") {
+		oldSerials, newSerials := d.GetChange("serial_numbers")
+		removed := interfaceToStrList(oldSerials.(*schema.Set).Difference(newSerials.(*schema.Set)).List())
+		added := interfaceToStrList(newSerials.(*schema.Set).Difference(oldSerials.(*schema.Set)).List())
+
+		if len(removed) > 0 {
+			err := detachImagePolicy(dcnmClient, removed)
+			if err != nil {
+				return diag.Errorf("error at detaching image policy %s: %s", policyName, err)
+			}
+		}
+		if len(added) > 0 {
+			err := attachImagePolicy(dcnmClient, d.Get("fabric_name").(string), policyName, added)
+			if err != nil {
+				return diag.Errorf("error at attaching image policy %s: %s", policyName, err)
+			}
+		}
+	}
+
+	log.Println("[DEBUG] End of Update method ", d.Id())
+	return resourceDCNMImagePolicyAttachmentRead(ctx, d, m)
+}
+
+func resourceDCNMImagePolicyAttachmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Read method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+	policyName := d.Get("policy_name").(string)
+
+	statusConts, err := getImageStatus(dcnmClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	configured := d.Get("serial_numbers").(*schema.Set)
+	serials := make([]string, 0, configured.Len())
+	for _, cont := range statusConts {
+		serial := models.G(cont, "serialNumber")
+		if configured.Contains(serial) && models.G(cont, "policy") == policyName {
+			serials = append(serials, serial)
+		}
+	}
+
+	if len(serials) == 0 {
+		log.Printf("[DEBUG] image policy %s is not attached to any switch", policyName)
+		d.SetId("")
+		return nil
+	}
+	d.Set("serial_numbers", serials)
+
+	log.Println("[DEBUG] End of Read method ", d.Id())
+	return nil
+}
+
+func resourceDCNMImagePolicyAttachmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Delete method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+
+	serials := interfaceToStrList(d.Get("serial_numbers").(*schema.Set).List())
+	err := detachImagePolicy(dcnmClient, serials)
+	if err != nil {
+		return diag.Errorf("error at detaching image policy %s: %s", d.Get("policy_name").(string), err)
+	}
+
+	d.SetId("")
+	log.Println("[DEBUG] End of Delete method ", d.Id())
+	return nil
+}
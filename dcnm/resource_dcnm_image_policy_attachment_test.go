@@ -0,0 +1,75 @@
+package dcnm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var providerfImagePolicyAttachment *schema.Provider
+
+func TestAccDCNMImagePolicyAttachment_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactoriesInternal(&providerfImagePolicyAttachment),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDCNMImagePolicyAttachmentConfig_basic(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMImagePolicyAttachmentExists("dcnm_image_policy_attachment.test"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDCNMImagePolicyAttachmentConfig_basic() string {
+	return `
+	resource "dcnm_image_policy" "test" {
+		name         = "acctest_policy"
+		platform     = "N9K"
+		nxos_version = "9.3.8_nxos64-cs_64bit"
+	}
+
+	resource "dcnm_image_policy_attachment" "test" {
+		fabric_name    = "fab2"
+		policy_name    = dcnm_image_policy.test.name
+		serial_numbers = ["9Y0K4YPFFOA"]
+	}
+	`
+}
+
+func testAccCheckDCNMImagePolicyAttachmentExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+
+		if !ok {
+			return fmt.Errorf("Image policy attachment %s not found", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Image policy attachment dn was set")
+		}
+
+		dcnmClient := (*providerfImagePolicyAttachment).Meta().(*client.Client)
+
+		statusMap, err := getImageStatusForSwitches(dcnmClient, []string{"9Y0K4YPFFOA"})
+		if err != nil {
+			return err
+		}
+
+		cont, ok := statusMap["9Y0K4YPFFOA"]
+		if !ok {
+			return fmt.Errorf("Switch not found in image management")
+		}
+		if policyGet := models.G(cont, "policy"); policyGet != "acctest_policy" {
+			return fmt.Errorf("Bad attached Image policy %s", policyGet)
+		}
+		return nil
+	}
+}
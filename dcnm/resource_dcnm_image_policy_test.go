@@ -0,0 +1,87 @@
+package dcnm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var providerfImagePolicy *schema.Provider
+
+func TestAccDCNMImagePolicy_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactoriesInternal(&providerfImagePolicy),
+		CheckDestroy:      testAccCheckDCNMImagePolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDCNMImagePolicyConfig_basic("first policy"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMImagePolicyExists("dcnm_image_policy.test", "first policy"),
+				),
+			},
+			{
+				Config: testAccCheckDCNMImagePolicyConfig_basic("second policy"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMImagePolicyExists("dcnm_image_policy.test", "second policy"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDCNMImagePolicyConfig_basic(descr string) string {
+	return fmt.Sprintf(`
+	resource "dcnm_image_policy" "test" {
+		name         = "acctest_policy"
+		platform     = "N9K"
+		nxos_version = "9.3.8_nxos64-cs_64bit"
+		description  = "%s"
+	}
+	`, descr)
+}
+
+func testAccCheckDCNMImagePolicyExists(name, descr string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+
+		if !ok {
+			return fmt.Errorf("Image policy %s not found", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Image policy dn was set")
+		}
+
+		dcnmClient := (*providerfImagePolicy).Meta().(*client.Client)
+
+		cont, err := getRemoteImagePolicy(dcnmClient, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if descrGet := models.G(cont, "policyDescr"); descrGet != descr {
+			return fmt.Errorf("Bad Image policy description %s", descrGet)
+		}
+		return nil
+	}
+}
+
+func testAccCheckDCNMImagePolicyDestroy(s *terraform.State) error {
+	dcnmClient := (*providerfImagePolicy).Meta().(*client.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "dcnm_image_policy" {
+			_, err := getRemoteImagePolicy(dcnmClient, rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("Image policy still exists")
+			}
+		}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package dcnm
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
@@ -9,16 +10,17 @@ import (
 	"github.com/ciscoecosystem/dcnm-go-client/client"
 	"github.com/ciscoecosystem/dcnm-go-client/container"
 	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceDCNMInterface() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDCNMInterfaceCreate,
-		Update: resourceDCNMInterfaceUpdate,
-		Read:   resourceDCNMInterfaceRead,
-		Delete: resourceDCNMInterfaceDelete,
+		CreateContext: resourceDCNMInterfaceCreate,
+		Update:        resourceDCNMInterfaceUpdate,
+		Read:          resourceDCNMInterfaceRead,
+		Delete:        resourceDCNMInterfaceDelete,
 
 		Importer: &schema.ResourceImporter{
 			State: resourceDCNMInterfaceImporter,
@@ -320,6 +322,12 @@ func resourceDCNMInterface() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+
+			"adopt_existing": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 	}
 }
@@ -535,7 +543,7 @@ func resourceDCNMInterfaceImporter(d *schema.ResourceData, m interface{}) ([]*sc
 	return []*schema.ResourceData{importState}, nil
 }
 
-func resourceDCNMInterfaceCreate(d *schema.ResourceData, m interface{}) error {
+func resourceDCNMInterfaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Println("[DEBUG] Begining Create method ")
 
 	dcnmClient := m.(*client.Client)
@@ -555,7 +563,7 @@ func resourceDCNMInterfaceCreate(d *schema.ResourceData, m interface{}) error {
 	switch1 := d.Get("switch_name_1")
 	switchCont, err := getRemoteSwitchforDS(dcnmClient, fabricName, switch1.(string))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	serial1 := stripQuotes(switchCont.S("serialNumber").String())
 
@@ -623,11 +631,11 @@ func resourceDCNMInterfaceCreate(d *schema.ResourceData, m interface{}) error {
 		if switch2, ok := d.GetOk("switch_name_2"); ok {
 			switchCont, err := getRemoteSwitchforDS(dcnmClient, fabricName, switch2.(string))
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 			serial2 = stripQuotes(switchCont.S("serialNumber").String())
 		} else {
-			return fmt.Errorf("switch_name_2 field is required for vpc interface")
+			return diag.Errorf("switch_name_2 field is required for vpc interface")
 		}
 
 		intf.Type = "INTERFACE_VPC"
@@ -815,22 +823,43 @@ func resourceDCNMInterfaceCreate(d *schema.ResourceData, m interface{}) error {
 		}
 
 	} else if intfType == "ethernet" {
-		return fmt.Errorf("Ethernet interface can only be modified")
+		return diag.Errorf("Ethernet interface can only be modified")
 
 	}
 
 	nvPairMap["ADMIN_STATE"] = d.Get("admin_state").(bool)
 	intfModel := models.NewInterface(&intf, &intfConfig, nvPairMap)
 
-	cont, err := dcnmClient.Save("/rest/interface", intfModel)
+	var existing *container.Container
+	if d.Get("adopt_existing").(bool) {
+		cont, err := getRemoteInterface(dcnmClient, strings.Split(intfConfig.SerialNumber, "~")[0], name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		existing = cont.Index(0)
+	}
+
+	var cont *container.Container
+	changes := make([]string, 0)
+	if existing != nil {
+		changes = interfaceAdoptChanges(policy, nvPairMap, existing)
+		if len(changes) == 0 {
+			log.Printf("[INFO] Adopting existing interface %s of switch %s, its settings match the configuration", name, intfConfig.SerialNumber)
+		} else {
+			log.Printf("[INFO] Adopting existing interface %s of switch %s, updating %s", name, intfConfig.SerialNumber, strings.Join(changes, ", "))
+			cont, err = dcnmClient.Update("/rest/interface", intfModel)
+		}
+	} else {
+		cont, err = dcnmClient.Save("/rest/interface", intfModel)
+	}
 	if err != nil {
 		if cont != nil {
 			errorMsg, flag := checkIntfErrors(cont)
 			if flag {
-				return fmt.Errorf(errorMsg)
+				return diag.Errorf("%s", errorMsg)
 			}
 		} else {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
@@ -839,7 +868,11 @@ func resourceDCNMInterfaceCreate(d *schema.ResourceData, m interface{}) error {
 	d.SetId(intfConfig.InterfaceName)
 
 	//Deployment of interface
-	if deploy, ok := d.GetOk("deploy"); ok && deploy.(bool) {
+	deployed := false
+	if existing != nil && len(changes) == 0 {
+		deployed, _ = checkIntfDeploy(dcnmClient, intfConfig.SerialNumber, name, intfType)
+	}
+	if deploy, ok := d.GetOk("deploy"); ok && deploy.(bool) && !deployed {
 		log.Println("[DEBUG] Begining Deployment ", d.Id())
 
 		intfDeploy := models.InterfaceDelete{}
@@ -849,9 +882,23 @@ func resourceDCNMInterfaceCreate(d *schema.ResourceData, m interface{}) error {
 		cont, err = dcnmClient.SaveForAttachment("/rest/interface/deploy", &intfDeploy)
 		if err != nil {
 			errorMsg, flag := checkIntfErrors(cont)
+			if flag && existing != nil {
+				// an adopted interface stays in the state instead of being
+				// tainted, so the next apply does not destroy it
+				if err := resourceDCNMInterfaceRead(d, m); err != nil {
+					return diag.Errorf("interface %s is adopted but failed to deploy: %s. Reading its state failed: %s", name, errorMsg, err)
+				}
+				return diag.Diagnostics{
+					{
+						Severity: diag.Warning,
+						Summary:  fmt.Sprintf("interface %s is adopted but failed to deploy", name),
+						Detail:   fmt.Sprintf("%s. The next apply retries the deployment.", errorMsg),
+					},
+				}
+			}
 			if flag {
 				d.Set("deploy", false)
-				return fmt.Errorf("interface is created but failed to deploy with error : %s", errorMsg)
+				return diag.Errorf("interface is created but failed to deploy with error : %s", errorMsg)
 			}
 		}
 
@@ -859,7 +906,18 @@ func resourceDCNMInterfaceCreate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	log.Println("[DEBUG] End of Create method ", d.Id())
-	return resourceDCNMInterfaceRead(d, m)
+	return diag.FromErr(resourceDCNMInterfaceRead(d, m))
+}
+
+// interfaceAdoptChanges returns the settings of an existing interface which
+// differ from the policy and nvPairs to be configured, nvPairs keys are
+// prefixed with nvPairs.
+func interfaceAdoptChanges(policy string, nvPairs map[string]interface{}, existing *container.Container) []string {
+	changes := settingsChanges(map[string]interface{}{"policy": policy}, existing)
+	for _, key := range settingsChanges(nvPairs, existing.S("interfaces").Index(0).S("nvPairs")) {
+		changes = append(changes, "nvPairs."+key)
+	}
+	return changes
 }
 
 func resourceDCNMInterfaceUpdate(d *schema.ResourceData, m interface{}) error {
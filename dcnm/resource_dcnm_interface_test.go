@@ -58,6 +58,64 @@ func TestAccDCNMInterface_Update(t *testing.T) {
 	})
 }
 
+func TestAccDCNMInterface_Adopt(t *testing.T) {
+	var intf models.Interface
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactoriesInternal(&providerIntf),
+		CheckDestroy:      testAccCheckDCNMInterfaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					testAccCreateUnmanaged(t, providerIntf, resourceDCNMInterface(), map[string]interface{}{
+						"fabric_name":               "fabric1",
+						"name":                      "loopback5",
+						"type":                      "loopback",
+						"policy":                    "int_loopback",
+						"switch_name_1":             "leaf1",
+						"ipv4":                      "1.2.3.4",
+						"loopback_tag":              "1234",
+						"vrf":                       "MyVRF",
+						"loopback_ls_routing":       "ospf",
+						"loopback_replication_mode": "Multicast",
+						"description":               "created outside terraform",
+						"ipv6":                      "2001::0",
+					})
+				},
+				Config: testAccCheckDCNMInterfaceConfig_adopt("adoption from terraform"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMInterfaceExists("dcnm_interface.test", &intf),
+					testAccCheckDCNMInterfaceAttributes("adoption from terraform", &intf),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDCNMInterfaceConfig_adopt(desc string) string {
+	return fmt.Sprintf(`
+	resource "dcnm_interface" "test" {
+		fabric_name = "fabric1"
+		name        = "loopback5"
+		type        = "loopback"
+		policy      = "int_loopback"
+
+		switch_name_1             = "leaf1"
+		ipv4                      = "1.2.3.4"
+		loopback_tag              = "1234"
+		vrf                       = "MyVRF"
+		loopback_ls_routing       = "ospf"
+		loopback_replication_mode = "Multicast"
+		description               = "%s"
+		ipv6                      = "2001::0"
+
+		adopt_existing = true
+		deploy         = false
+	}
+	`, desc)
+}
+
 func testAccCheckDCNMInterfaceConfig_basic(desc string) string {
 	return fmt.Sprintf(`
 	resource "dcnm_interface" "test" {
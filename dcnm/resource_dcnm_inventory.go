@@ -222,6 +222,10 @@ func extractSerialNumber(cont *container.Container, ip string) (string, error) {
 	return models.G(infoCont, "serialNumber"), nil
 }
 
+// errSwitchNotFound is returned by getRemoteSwitch when the fabric inventory
+// does not contain the switch.
+var errSwitchNotFound = fmt.Errorf("desired switch not found")
+
 func getRemoteSwitch(dcnmClient *client.Client, fabric, ip, serialNum string) (*container.Container, error) {
 	durl := fmt.Sprintf("/rest/control/fabrics/%s/inventory", fabric)
 	cont, err := dcnmClient.GetviaURL(durl)
@@ -237,7 +241,7 @@ func getRemoteSwitch(dcnmClient *client.Client, fabric, ip, serialNum string) (*
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("desired switch not found")
+		return nil, errSwitchNotFound
 	}
 
 	return infoCont, nil
@@ -495,13 +499,7 @@ func resourceDCNMInventoryUpdate(ctx context.Context, d *schema.ResourceData, m
 
 			switchDbID := models.G(cont, "switchDbID")
 
-			body := []byte(fmt.Sprintf("switchIds=%s&userName=%s&password=%s&v3protocol=%s", switchDbID, inv.Username, inv.Password, strconv.Itoa(auth)))
-
-			durl := "/fm/fmrest/lanConfig/saveSwitchCredentials"
-			if dcnmClient.GetPlatform() == "nd" {
-				durl = "/rest/lanConfig/saveSwitchCredentials"
-			}
-			_, err = dcnmClient.UpdateCred(durl, body)
+			err = saveSwitchCredentials(dcnmClient, []string{switchDbID}, inv.Username, inv.Password, auth)
 			if err != nil {
 				log.Printf("\nerror at credential update of switch %s: %s", ip, err)
 			}
@@ -0,0 +1,639 @@
+package dcnm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/container"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var poapURLs = map[string]string{
+	"Common": "/rest/control/fabrics/%s/inventory/poap",
+}
+
+func resourceDCNMInventoryPOAP() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDCNMInventoryPOAPCreate,
+		UpdateContext: resourceDCNMInventoryPOAPUpdate,
+		ReadContext:   resourceDCNMInventoryPOAPRead,
+		DeleteContext: resourceDCNMInventoryPOAPDelete,
+		CustomizeDiff: resourceDCNMInventoryPOAPCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"fabric_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"password": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+
+			"auth_protocol": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
+
+			"discovery_username": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"discovery_password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+
+			"switch_config": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"serial_number": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"hostname": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"ip": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"gateway": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"model": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+
+						"version": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+
+						"image_policy": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+
+						"modules_model": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+
+						"role": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"leaf",
+								"spine",
+								"border",
+								"border_spine",
+								"border_gateway",
+								"border_gateway_spine",
+								"super_spine",
+								"border_super_spine",
+								"border_gateway_super_spine",
+							}, false),
+						},
+
+						"preprovision": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+
+						"switch_db_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"mode": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Set: resourceDCNMPOAPSwitchConfigHash,
+			},
+
+			"config_timeout": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  20,
+			},
+
+			"deploy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+func resourceDCNMPOAPSwitchConfigHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+
+	buf.WriteString(fmt.Sprintf("%s-", m["serial_number"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["hostname"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["ip"].(string)))
+	buf.WriteString(fmt.Sprintf("%t-", m["preprovision"].(bool)))
+
+	return hashString(buf.String())
+}
+
+func getPOAPSwitches(dcnmClient *client.Client, fabricName string) (*container.Container, error) {
+	return dcnmClient.GetviaURL(fmt.Sprintf(poapURLs["Common"], fabricName))
+}
+
+func getPOAPSwitchPayload(d *schema.ResourceData, poapCont *container.Container, sInfo map[string]interface{}) (map[string]interface{}, error) {
+	serialNum := sInfo["serial_number"].(string)
+	model := sInfo["model"].(string)
+	version := sInfo["version"].(string)
+	modules := interfaceToStrList(sInfo["modules_model"])
+
+	if sInfo["preprovision"].(bool) {
+		if model == "" || version == "" || len(modules) == 0 {
+			return nil, fmt.Errorf("model, version and modules_model must be configured to pre-provision switch %s", serialNum)
+		}
+	} else {
+		bootCont, err := poapCont.SearchInObjectList(func(tempCont *container.Container) bool {
+			return models.G(tempCont, "serialNumber") == serialNum
+		})
+		if err != nil {
+			return nil, fmt.Errorf("switch %s is not in the POAP list of fabric %s", serialNum, d.Get("fabric_name").(string))
+		}
+		if model == "" {
+			model = models.G(bootCont, "model")
+		}
+		if version == "" {
+			version = models.G(bootCont, "version")
+		}
+		if len(modules) == 0 {
+			if dataCont, err := cleanJsonString(models.G(bootCont, "data")); err == nil && dataCont.Exists("modulesModel") {
+				modules = interfaceToStrList(dataCont.S("modulesModel").Data())
+			}
+		}
+	}
+
+	data, err := json.Marshal(map[string]interface{}{
+		"gateway":      sInfo["gateway"].(string),
+		"modulesModel": modules,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"serialNumber":          serialNum,
+		"model":                 model,
+		"version":               version,
+		"hostname":              sInfo["hostname"].(string),
+		"ipAddress":             sInfo["ip"].(string),
+		"password":              d.Get("password").(string),
+		"discoveryAuthProtocol": d.Get("auth_protocol").(int),
+		"data":                  string(data),
+	}
+	if policy := sInfo["image_policy"].(string); policy != "" {
+		payload["imagePolicy"] = policy
+	}
+	if username, ok := d.GetOk("discovery_username"); ok {
+		payload["discoveryUsername"] = username.(string)
+		payload["discoveryPassword"] = d.Get("discovery_password").(string)
+	}
+
+	return payload, nil
+}
+
+func onboardPOAPSwitches(d *schema.ResourceData, dcnmClient *client.Client, switchInfos []map[string]interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	fabricName := d.Get("fabric_name").(string)
+
+	poapCont, err := getPOAPSwitches(dcnmClient, fabricName)
+	if err != nil {
+		return diag.Errorf("error at fetching POAP list of fabric %s: %s", fabricName, err)
+	}
+
+	payload := make([]interface{}, 0, len(switchInfos))
+	for _, sInfo := range switchInfos {
+		switchPayload, err := getPOAPSwitchPayload(d, poapCont, sInfo)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		payload = append(payload, switchPayload)
+	}
+
+	_, err = makeAndDoRequest(dcnmClient, "POST", fmt.Sprintf(poapURLs["Common"], fabricName), payload, false)
+	if err != nil {
+		return diag.Errorf("error at bootstrap of switches: %s", err)
+	}
+	// the switches are in the fabric from here on, so they are tracked even
+	// if waiting for them fails and the resource is tainted
+	d.SetId(strings.Join(getPOAPSerials(d.Get("switch_config").(*schema.Set).List()), ","))
+
+	switchWaitGroup := new(sync.WaitGroup)
+	poapDiagsChan := make(chan diag.Diagnostics, len(switchInfos))
+	configTimeout := d.Get("config_timeout").(int) * 60
+
+	for _, sInfo := range switchInfos {
+		switchWaitGroup.Add(1)
+		go poapSwitchRoutine(switchWaitGroup, dcnmClient, fabricName, configTimeout, sInfo, poapDiagsChan)
+	}
+
+	switchWaitGroup.Wait()
+	close(poapDiagsChan)
+
+	for poapDiags := range poapDiagsChan {
+		diags = append(diags, poapDiags...)
+	}
+	return diags
+}
+
+// poapSwitchRoutine waits until a bootstrapped switch has booted and is managed
+// by the fabric, then assigns its role. Pre-provisioned switches are not
+// expected to boot yet, so only the role is assigned.
+func poapSwitchRoutine(wg *sync.WaitGroup, dcnmClient *client.Client, fabricName string, configTimeout int, switchInfo map[string]interface{}, poapDiagsChan chan diag.Diagnostics) {
+	defer wg.Done()
+	var diags diag.Diagnostics
+
+	serialNum := switchInfo["serial_number"].(string)
+
+	if !switchInfo["preprovision"].(bool) {
+		managed := false
+		initTime := time.Now()
+		for time.Since(initTime) < (time.Duration(configTimeout) * time.Second) {
+			time.Sleep(10 * time.Second)
+			cont, err := getRemoteSwitch(dcnmClient, fabricName, "", serialNum)
+			if err != nil {
+				log.Println("Error at get call for switch in bootstrap :", serialNum, err)
+				continue
+			}
+
+			if models.G(cont, "mode") != "Migration" && models.G(cont, "status") == "ok" && models.G(cont, "managable") != "false" {
+				managed = true
+				break
+			}
+		}
+
+		if !managed {
+			diags = append(diags, diag.Errorf("timeout occurs before switch %s finished booting and became managed", serialNum)...)
+			poapDiagsChan <- diags
+			return
+		}
+	}
+
+	if role := switchInfo["role"].(string); role != "" {
+		_, err := dcnmClient.SaveForAttachment(
+			"/rest/control/switches/roles",
+			&models.SwitchRole{
+				Role:         roleMappingFunc(role),
+				SerialNumber: serialNum,
+			},
+		)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Error at switch role assignment %s: %s", serialNum, err),
+			})
+		}
+	}
+
+	poapDiagsChan <- diags
+}
+
+// resourceDCNMInventoryPOAPCustomizeDiff fails the plan if bootstrap settings
+// of a switch already in switch_config are changed, only its role can be
+// updated in place.
+func resourceDCNMInventoryPOAPCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if diff.Id() == "" || !diff.HasChange("switch_config") {
+		return nil
+	}
+	switchInfosOld, switchInfosNew := diff.GetChange("switch_config")
+	return checkPOAPSwitchChanges(switchInfosOld.(*schema.Set).List(), switchInfosNew.(*schema.Set).List())
+}
+
+// checkPOAPSwitchChanges returns an error for the first switch in both old and
+// new whose bootstrap settings differ.
+func checkPOAPSwitchChanges(switchInfosOld, switchInfosNew []interface{}) error {
+	oldInfos := make(map[string]map[string]interface{})
+	for _, val := range switchInfosOld {
+		sInfo := val.(map[string]interface{})
+		oldInfos[sInfo["serial_number"].(string)] = sInfo
+	}
+	for _, val := range switchInfosNew {
+		sInfo := val.(map[string]interface{})
+		serialNum := sInfo["serial_number"].(string)
+		if oldInfo, ok := oldInfos[serialNum]; ok {
+			if changed := poapSwitchChanges(oldInfo, sInfo); len(changed) > 0 {
+				return fmt.Errorf("%s of switch %s can not be updated, remove the switch from switch_config and add it again to bootstrap it with the new values", strings.Join(changed, ", "), serialNum)
+			}
+		}
+	}
+	return nil
+}
+
+// poapSwitchChanges returns the attributes of a bootstrapped switch, other
+// than its role, which differ between old and new. Unset computed attributes
+// of new keep the value of old.
+func poapSwitchChanges(old, new map[string]interface{}) []string {
+	changed := make([]string, 0)
+	for _, key := range []string{"hostname", "ip", "gateway", "image_policy", "preprovision"} {
+		if !reflect.DeepEqual(old[key], new[key]) {
+			changed = append(changed, key)
+		}
+	}
+	for _, key := range []string{"model", "version"} {
+		if value := new[key].(string); value != "" && value != old[key].(string) {
+			changed = append(changed, key)
+		}
+	}
+	if modules := interfaceToStrList(new["modules_model"]); len(modules) > 0 && !compareStrLists(modules, interfaceToStrList(old["modules_model"])) {
+		changed = append(changed, "modules_model")
+	}
+	return changed
+}
+
+func getPOAPSerials(switchInfos []interface{}) []string {
+	serials := make([]string, 0, len(switchInfos))
+	for _, val := range switchInfos {
+		serials = append(serials, val.(map[string]interface{})["serial_number"].(string))
+	}
+	return serials
+}
+
+func deletePOAPSwitches(dcnmClient *client.Client, fabricName string, serials []string) error {
+	for _, serialNum := range serials {
+		durl := fmt.Sprintf("/rest/control/fabrics/%s/switches/%s", fabricName, serialNum)
+		_, err := dcnmClient.Delete(durl)
+		if err != nil {
+			return fmt.Errorf("error at deletion of switch %s: %s", serialNum, err)
+		}
+	}
+	return nil
+}
+
+func resourceDCNMInventoryPOAPCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Create method ")
+
+	dcnmClient := m.(*client.Client)
+	fabricName := d.Get("fabric_name").(string)
+
+	switchInfos := make([]map[string]interface{}, 0, 1)
+	for _, val := range d.Get("switch_config").(*schema.Set).List() {
+		switchInfos = append(switchInfos, val.(map[string]interface{}))
+	}
+
+	diags := onboardPOAPSwitches(d, dcnmClient, switchInfos)
+	if diags.HasError() {
+		return diags
+	}
+
+	if d.Get("deploy").(bool) {
+		err := deployFabric(dcnmClient, fabricName)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("error at fabric deployment: %s", err),
+			})
+		}
+	}
+
+	d.SetId(strings.Join(getPOAPSerials(d.Get("switch_config").(*schema.Set).List()), ","))
+
+	log.Println("[DEBUG] End of Create method ", d.Id())
+	return append(diags, resourceDCNMInventoryPOAPRead(ctx, d, m)...)
+}
+
+func resourceDCNMInventoryPOAPUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Update method ", d.Id())
+
+	var diags diag.Diagnostics
+	dcnmClient := m.(*client.Client)
+	fabricName := d.Get("fabric_name").(string)
+
+	if d.HasChange("switch_config") {
+		switchInfosOld, switchInfosNew := d.GetChange("switch_config")
+		oldSerials := getPOAPSerials(switchInfosOld.(*schema.Set).List())
+		newSerials := getPOAPSerials(switchInfosNew.(*schema.Set).List())
+
+		// remove switches that is removed from config
+		err := deletePOAPSwitches(dcnmClient, fabricName, setDifference(oldSerials, newSerials))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		existingSerials := make(map[string]bool)
+		for _, serialNum := range oldSerials {
+			existingSerials[serialNum] = true
+		}
+
+		createInfos := make([]map[string]interface{}, 0, 1)
+		for _, val := range switchInfosNew.(*schema.Set).List() {
+			sInfo := val.(map[string]interface{})
+			serialNum := sInfo["serial_number"].(string)
+
+			if existingSerials[serialNum] {
+				// update switch roles for existing switches
+				if role := sInfo["role"].(string); role != "" {
+					_, err := dcnmClient.SaveForAttachment(
+						"/rest/control/switches/roles",
+						&models.SwitchRole{
+							Role:         roleMappingFunc(role),
+							SerialNumber: serialNum,
+						},
+					)
+					if err != nil {
+						diags = append(diags, diag.Diagnostic{
+							Severity: diag.Warning,
+							Summary:  fmt.Sprintf("error at switch role assignment %s: %s", serialNum, err),
+						})
+					}
+				}
+			} else {
+				createInfos = append(createInfos, sInfo)
+			}
+		}
+
+		if len(createInfos) > 0 {
+			diags = append(diags, onboardPOAPSwitches(d, dcnmClient, createInfos)...)
+			if diags.HasError() {
+				return diags
+			}
+		}
+
+		if d.Get("deploy").(bool) {
+			err := deployFabric(dcnmClient, fabricName)
+			if err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("error at fabric deployment: %s", err),
+				})
+			}
+		}
+
+		d.SetId(strings.Join(newSerials, ","))
+	}
+
+	log.Println("[DEBUG] End of Update method ", d.Id())
+	return append(diags, resourceDCNMInventoryPOAPRead(ctx, d, m)...)
+}
+
+func resourceDCNMInventoryPOAPRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Read method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+	fabricName := d.Get("fabric_name").(string)
+
+	durl := fmt.Sprintf("/rest/control/fabrics/%s/inventory", fabricName)
+	cont, err := dcnmClient.GetviaURL(durl)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// image policies and the bootstrap data of pre-provisioned switches are
+	// not part of the inventory
+	imagePolicies := make(map[string]string)
+	if statusConts, err := getImageStatus(dcnmClient); err == nil {
+		for _, statusCont := range statusConts {
+			imagePolicies[models.G(statusCont, "serialNumber")] = models.G(statusCont, "policy")
+		}
+	} else {
+		log.Println("error in read at fetching image policies :", err)
+	}
+	poapCont, err := getPOAPSwitches(dcnmClient, fabricName)
+	if err != nil {
+		log.Println("error in read at fetching POAP list :", err)
+	}
+
+	switchConfigGet := make([]interface{}, 0, 1)
+	serials := make([]string, 0, 1)
+
+	for _, val := range d.Get("switch_config").(*schema.Set).List() {
+		sInfo := val.(map[string]interface{})
+		serialNum := sInfo["serial_number"].(string)
+
+		switchCont, err := cont.SearchInObjectList(func(tempCont *container.Container) bool {
+			return models.G(tempCont, "serialNumber") == serialNum
+		})
+		if err != nil {
+			log.Println("switch not found in inventory :", serialNum)
+			continue
+		}
+
+		sInfo["hostname"] = models.G(switchCont, "logicalName")
+		sInfo["switch_db_id"] = models.G(switchCont, "switchDbID")
+		sInfo["model"] = models.G(switchCont, "model")
+		sInfo["mode"] = models.G(switchCont, "mode")
+		if release := models.G(switchCont, "release"); release != "null" && release != "" {
+			sInfo["version"] = release
+		}
+		if ip := models.G(switchCont, "ipAddress"); ip != "null" && ip != "" {
+			sInfo["ip"] = ip
+		}
+
+		if policy, ok := imagePolicies[serialNum]; ok {
+			if policy == "None" || policy == "null" {
+				policy = ""
+			}
+			sInfo["image_policy"] = policy
+		}
+		if gateway := poapGateway(poapCont, serialNum); gateway != "" {
+			sInfo["gateway"] = gateway
+		}
+
+		role, err := getSwitchRole(dcnmClient, serialNum)
+		if err == nil {
+			sInfo["role"] = strings.ReplaceAll(strings.Trim(role, " "), " ", "_")
+		} else {
+			log.Println("error in read at fetching switch role :", serialNum, err)
+		}
+
+		serials = append(serials, serialNum)
+		switchConfigGet = append(switchConfigGet, sInfo)
+	}
+
+	if len(serials) == 0 {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("switch_config", switchConfigGet)
+	d.SetId(strings.Join(serials, ","))
+
+	log.Println("[DEBUG] End of Read method ", d.Id())
+	return nil
+}
+
+// poapGateway returns the gateway in the bootstrap data of a switch of the
+// POAP list, which keeps pre-provisioned switches until they boot.
+func poapGateway(poapCont *container.Container, serialNum string) string {
+	if poapCont == nil {
+		return ""
+	}
+	bootCont, err := poapCont.SearchInObjectList(func(tempCont *container.Container) bool {
+		return models.G(tempCont, "serialNumber") == serialNum
+	})
+	if err != nil {
+		return ""
+	}
+	dataCont, err := getTemplateConfig(bootCont, "data")
+	if err != nil {
+		return ""
+	}
+	if gateway := models.G(dataCont, "gateway"); gateway != "null" {
+		return gateway
+	}
+	return ""
+}
+
+func resourceDCNMInventoryPOAPDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Delete method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+	fabricName := d.Get("fabric_name").(string)
+
+	err := deletePOAPSwitches(dcnmClient, fabricName, strings.Split(d.Id(), ","))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	log.Println("[DEBUG] End of Delete method ", d.Id())
+	return nil
+}
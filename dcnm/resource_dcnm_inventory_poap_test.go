@@ -0,0 +1,169 @@
+package dcnm
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/container"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var providerfInvPOAP *schema.Provider
+
+func TestPOAPSwitchChanges(t *testing.T) {
+	old := map[string]interface{}{
+		"hostname":      "leaf1",
+		"ip":            "10.0.0.11",
+		"gateway":       "10.0.0.1/24",
+		"model":         "N9K-C9300v",
+		"version":       "9.3(8)",
+		"modules_model": []interface{}{"N9K-X9364v", "N9K-vSUP"},
+		"image_policy":  "",
+		"role":          "leaf",
+		"preprovision":  false,
+	}
+
+	cases := []struct {
+		changes  map[string]interface{}
+		expected []string
+	}{
+		{map[string]interface{}{"role": "border"}, []string{}},
+		{map[string]interface{}{"model": "", "version": "", "modules_model": []interface{}{}}, []string{}},
+		{map[string]interface{}{"hostname": "leaf2", "gateway": "10.0.0.254/24"}, []string{"hostname", "gateway"}},
+		{map[string]interface{}{"version": "10.2(3)", "modules_model": []interface{}{"N9K-X9364v"}}, []string{"version", "modules_model"}},
+		{map[string]interface{}{"preprovision": true, "image_policy": "nxos-10"}, []string{"image_policy", "preprovision"}},
+	}
+
+	for _, c := range cases {
+		new := make(map[string]interface{})
+		for key, value := range old {
+			new[key] = value
+		}
+		for key, value := range c.changes {
+			new[key] = value
+		}
+		if changed := poapSwitchChanges(old, new); !reflect.DeepEqual(changed, c.expected) {
+			t.Errorf("%v: expected %v, got %v", c.changes, c.expected, changed)
+		}
+	}
+}
+
+func TestCheckPOAPSwitchChanges(t *testing.T) {
+	old := []interface{}{
+		map[string]interface{}{"serial_number": "9A1", "hostname": "leaf1", "ip": "10.0.0.11", "gateway": "10.0.0.1/24", "image_policy": "", "preprovision": false, "model": "N9K-C9300v", "version": "9.3(8)", "modules_model": []interface{}{}},
+	}
+
+	cases := []struct {
+		new    []interface{}
+		failed bool
+	}{
+		// a new switch is bootstrapped, the existing one is unchanged
+		{append(old, map[string]interface{}{"serial_number": "9A2", "hostname": "leaf2", "ip": "10.0.0.12", "gateway": "10.0.0.1/24", "image_policy": "", "preprovision": false, "model": "", "version": "", "modules_model": []interface{}{}}), false},
+		{[]interface{}{map[string]interface{}{"serial_number": "9A1", "hostname": "leaf1", "ip": "10.0.0.11", "gateway": "10.0.0.254/24", "image_policy": "", "preprovision": false, "model": "", "version": "", "modules_model": []interface{}{}}}, true},
+		{[]interface{}{}, false},
+	}
+
+	for i, c := range cases {
+		if err := checkPOAPSwitchChanges(old, c.new); (err != nil) != c.failed {
+			t.Errorf("case %d: expected failure %t, got %v", i, c.failed, err)
+		}
+	}
+}
+
+func TestPOAPGateway(t *testing.T) {
+	poapCont, err := container.ParseJSON([]byte(`[
+		{"serialNumber":"9A1","data":"{\"gateway\": \"10.0.0.1/24\", \"modulesModel\": [\"N9K-X9364v\"]}"},
+		{"serialNumber":"9A2","data":"{\"modulesModel\": []}"}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for serial, expected := range map[string]string{"9A1": "10.0.0.1/24", "9A2": "", "9A3": ""} {
+		if gateway := poapGateway(poapCont, serial); gateway != expected {
+			t.Errorf("switch %s: expected gateway %q, got %q", serial, expected, gateway)
+		}
+	}
+	if gateway := poapGateway(nil, "9A1"); gateway != "" {
+		t.Errorf("expected no gateway without a POAP list, got %q", gateway)
+	}
+}
+
+func TestAccDCNMInventoryPOAP_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactoriesInternal(&providerfInvPOAP),
+		CheckDestroy:      testAccCheckDCNMInventoryPOAPDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDCNMInventoryPOAPConfig_basic("9Y0K4YPFPRE"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMInventoryPOAPExists("dcnm_inventory_poap.test"),
+					resource.TestCheckResourceAttr("dcnm_inventory_poap.test", "id", "9Y0K4YPFPRE"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDCNMInventoryPOAPConfig_basic(serial string) string {
+	return fmt.Sprintf(`
+	resource "dcnm_inventory_poap" "test" {
+		fabric_name = "fab2"
+		password    = "ins3965!"
+		deploy      = false
+		switch_config {
+			serial_number = "%s"
+			hostname      = "leaf-tf-poap"
+			ip            = "172.25.74.110"
+			gateway       = "172.25.74.1/24"
+			model         = "N9K-C93180YC-EX"
+			version       = "9.3(8)"
+			modules_model = ["N9K-C93180YC-EX"]
+			role          = "leaf"
+			preprovision  = true
+		}
+	}
+	`, serial)
+}
+
+func testAccCheckDCNMInventoryPOAPExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+
+		if !ok {
+			return fmt.Errorf("POAP inventory %s not found", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No POAP inventory dn was set")
+		}
+
+		dcnmClient := (*providerfInvPOAP).Meta().(*client.Client)
+
+		_, err := getRemoteSwitch(dcnmClient, "fab2", "", rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+}
+
+func testAccCheckDCNMInventoryPOAPDestroy(s *terraform.State) error {
+	dcnmClient := (*providerfInvPOAP).Meta().(*client.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "dcnm_inventory_poap" {
+			_, err := getRemoteSwitch(dcnmClient, "fab2", "", rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("POAP switch still exists")
+			}
+		}
+	}
+
+	return nil
+}
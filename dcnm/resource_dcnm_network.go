@@ -1,6 +1,7 @@
 package dcnm
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -11,20 +12,31 @@ import (
 	"github.com/ciscoecosystem/dcnm-go-client/client"
 	"github.com/ciscoecosystem/dcnm-go-client/container"
 	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceDCNMNetwork() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDCNMNetworkCreate,
-		Update: resourceDCNMNetworkUpdate,
-		Read:   resourceDCNMNetworkRead,
-		Delete: resourceDCNMNetworkDelete,
+		CreateContext: resourceDCNMNetworkCreate,
+		Update:        resourceDCNMNetworkUpdate,
+		Read:          resourceDCNMNetworkRead,
+		Delete:        resourceDCNMNetworkDelete,
 
 		Importer: &schema.ResourceImporter{
 			State: resourceDCNMNetworkImporter,
 		},
 
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceDCNMNetworkV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceDCNMNetworkStateUpgradeV0,
+				Version: 0,
+			},
+		},
+
 		Schema: map[string]*schema.Schema{
 			"fabric_name": &schema.Schema{
 				Type:     schema.TypeString,
@@ -110,28 +122,14 @@ func resourceDCNMNetwork() *schema.Resource {
 				Computed: true,
 			},
 
-			"secondary_gw_1": &schema.Schema{
-				Type:     schema.TypeString,
+			"secondary_gateways": &schema.Schema{
+				Type:     schema.TypeList,
 				Optional: true,
-				Computed: true,
-			},
-
-			"secondary_gw_2": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-			},
-
-			"secondary_gw_3": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-			},
-
-			"secondary_gw_4": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
+				MaxItems: 4,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsCIDR,
+				},
 			},
 
 			"arp_supp_flag": &schema.Schema{
@@ -152,34 +150,24 @@ func resourceDCNMNetwork() *schema.Resource {
 				Computed: true,
 			},
 
-			"dhcp_1": &schema.Schema{
-				Type:     schema.TypeString,
+			"dhcp_relay": &schema.Schema{
+				Type:     schema.TypeList,
 				Optional: true,
-			},
-
-			"dhcp_2": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-
-			"dhcp_3": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-
-			"dhcp_vrf": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-
-			"dhcp_vrf_2": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-			},
+				MaxItems: 16,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"server_address": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsIPAddress,
+						},
 
-			"dhcp_vrf_3": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
+						"vrf_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
 			},
 
 			"loopback_id": &schema.Schema{
@@ -223,6 +211,15 @@ func resourceDCNMNetwork() *schema.Resource {
 				Computed: true,
 			},
 
+			"template_props": &schema.Schema{
+				Type:         schema.TypeMap,
+				Optional:     true,
+				ValidateFunc: validateTemplateProps(append(templateConfigKeys(models.NetworkProfileConfig{}), "dhcpServers")...),
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
 			"deploy": &schema.Schema{
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -235,6 +232,30 @@ func resourceDCNMNetwork() *schema.Resource {
 				Default:  300,
 			},
 
+			"on_create_failure": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "partial",
+				ValidateFunc: validation.StringInSlice([]string{
+					"partial",
+					"rollback",
+				}, false),
+			},
+
+			"adopt_existing": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"attached_switches": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
 			"netflow_flag": &schema.Schema{
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -320,6 +341,16 @@ func resourceDCNMNetwork() *schema.Resource {
 							Optional: true,
 							Computed: true,
 						},
+
+						"deployment_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"failure_reason": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
 					},
 				},
 			},
@@ -356,7 +387,7 @@ func setNetworkAttributes(d *schema.ResourceData, cont *container.Container) *sc
 		d.Set("source", stripQuotes(cont.S("source").String()))
 	}
 
-	cont, err := cleanJsonString(stripQuotes(cont.S("networkTemplateConfig").String()))
+	cont, err := getTemplateConfig(cont, "networkTemplateConfig")
 	if err == nil {
 		if cont.Exists("isLayer2Only") && stripQuotes(cont.S("isLayer2Only").String()) != "" {
 			if l2, err := strconv.ParseBool(stripQuotes(cont.S("isLayer2Only").String())); err == nil {
@@ -388,18 +419,7 @@ func setNetworkAttributes(d *schema.ResourceData, cont *container.Container) *sc
 				d.Set("mtu", mtu)
 			}
 		}
-		if cont.Exists("secondaryGW1") {
-			d.Set("secondary_gw_1", stripQuotes(cont.S("secondaryGW1").String()))
-		}
-		if cont.Exists("secondaryGW2") {
-			d.Set("secondary_gw_2", stripQuotes(cont.S("secondaryGW2").String()))
-		}
-		if cont.Exists("secondaryGW3") {
-			d.Set("secondary_gw_3", stripQuotes(cont.S("secondaryGW3").String()))
-		}
-		if cont.Exists("secondaryGW4") {
-			d.Set("secondary_gw_4", stripQuotes(cont.S("secondaryGW4").String()))
-		}
+		d.Set("secondary_gateways", getNetworkSecondaryGateways(cont))
 		if cont.Exists("suppressArp") && stripQuotes(cont.S("suppressArp").String()) != "" {
 			if arp, err := strconv.ParseBool(stripQuotes(cont.S("suppressArp").String())); err == nil {
 				d.Set("arp_supp_flag", arp)
@@ -417,24 +437,7 @@ func setNetworkAttributes(d *schema.ResourceData, cont *container.Container) *sc
 		if cont.Exists("mcastGroup") {
 			d.Set("mcast_group", stripQuotes(cont.S("mcastGroup").String()))
 		}
-		if cont.Exists("dhcpServerAddr1") {
-			d.Set("dhcp_1", stripQuotes(cont.S("dhcpServerAddr1").String()))
-		}
-		if cont.Exists("dhcpServerAddr2") {
-			d.Set("dhcp_2", stripQuotes(cont.S("dhcpServerAddr2").String()))
-		}
-		if cont.Exists("dhcpServerAddr3") {
-			d.Set("dhcp_3", stripQuotes(cont.S("dhcpServerAddr3").String()))
-		}
-		if cont.Exists("vrfDhcp") {
-			d.Set("dhcp_vrf", stripQuotes(cont.S("vrfDhcp").String()))
-		}
-		if cont.Exists("vrfDhcp2") {
-			d.Set("dhcp_vrf_2", stripQuotes(cont.S("vrfDhcp2").String()))
-		}
-		if cont.Exists("vrfDhcp3") {
-			d.Set("dhcp_vrf_3", stripQuotes(cont.S("vrfDhcp3").String()))
-		}
+		d.Set("dhcp_relay", getNetworkDHCPRelays(cont))
 		if cont.Exists("loopbackId") && stripQuotes(cont.S("loopbackId").String()) != "" {
 			if loopback, err := strconv.Atoi(stripQuotes(cont.S("loopbackId").String())); err == nil {
 				d.Set("loopback_id", loopback)
@@ -519,7 +522,7 @@ func resourceDCNMNetworkImporter(d *schema.ResourceData, m interface{}) ([]*sche
 	return []*schema.ResourceData{stateImport}, nil
 }
 
-func resourceDCNMNetworkCreate(d *schema.ResourceData, m interface{}) error {
+func resourceDCNMNetworkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Println("[DEBUG] Begining Create method ")
 
 	dcnmClient := m.(*client.Client)
@@ -529,27 +532,40 @@ func resourceDCNMNetworkCreate(d *schema.ResourceData, m interface{}) error {
 
 	if deploy, ok := d.GetOk("deploy"); ok && deploy.(bool) == true {
 		if _, ok := d.GetOk("attachments"); !ok {
-			return fmt.Errorf("attachments must be configured if deploy=true")
+			return diag.Errorf("attachments must be configured if deploy=true")
+		}
+	}
+
+	var existing *container.Container
+	if d.Get("adopt_existing").(bool) {
+		if cont, err := getRemoteNetwork(dcnmClient, fabricName, name); err == nil && models.G(cont, "networkName") == name {
+			existing = cont
 		}
 	}
 
+	// IDs allocated here are released again on a rollback
+	reservations := make([]resourceReservation, 0)
+
 	var segID string
 	if nid, ok := d.GetOk("network_id"); ok {
 		segID = nid.(string)
+	} else if existing != nil {
+		segID = models.G(existing, "networkId")
 	} else {
 		if dcnmClient.GetPlatform() == "nd" {
 			cont, err := dcnmClient.GetviaURL(fmt.Sprintf("/rest/top-down/fabrics/%s/netinfo", fabricName))
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 			segID = cont.S("l2vni").String()
 		} else {
 			cont, err := dcnmClient.GetSegID(fmt.Sprintf("/rest/managed-pool/fabrics/%s/segments/ids", fabricName))
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 			segID = cont.S("segmentId").String()
 		}
+		reservations = append(reservations, resourceReservation{Pool: "L2_VNI", Value: stripQuotes(segID)})
 	}
 
 	network := models.Network{}
@@ -587,15 +603,20 @@ func resourceDCNMNetworkCreate(d *schema.ResourceData, m interface{}) error {
 	}
 	if vlan, ok := d.GetOk("vlan_id"); ok {
 		networkProfile.Vlan = strconv.Itoa(vlan.(int))
+	} else if existing != nil {
+		if configCont, err := getTemplateConfig(existing, "networkTemplateConfig"); err == nil {
+			networkProfile.Vlan = models.G(configCont, "vlanId")
+		}
 	} else {
 		durl := fmt.Sprintf("/rest/resource-manager/vlan/%s?vlanUsageType=TOP_DOWN_NETWORK_VLAN", fabricName)
 		cont, err := dcnmClient.GetviaURL(durl)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		vlan := cont.String()
 		if err == nil {
 			networkProfile.Vlan = vlan
+			reservations = append(reservations, resourceReservation{Pool: "TOP_DOWN_NETWORK_VLAN", Value: stripQuotes(vlan)})
 		}
 	}
 	if vlanName, ok := d.GetOk("vlan_name"); ok {
@@ -609,17 +630,9 @@ func resourceDCNMNetworkCreate(d *schema.ResourceData, m interface{}) error {
 	} else {
 		networkProfile.MTU = ""
 	}
-	if secgw1, ok := d.GetOk("secondary_gw_1"); ok {
-		networkProfile.SecondaryGate1 = secgw1.(string)
-	}
-	if secgw2, ok := d.GetOk("secondary_gw_2"); ok {
-		networkProfile.SecondaryGate2 = secgw2.(string)
-	}
-	if secgw3, ok := d.GetOk("secondary_gw_3"); ok {
-		networkProfile.SecondaryGate3 = secgw3.(string)
-	}
-	if secgw4, ok := d.GetOk("secondary_gw_4"); ok {
-		networkProfile.SecondaryGate4 = secgw4.(string)
+	templateProps, err := networkTemplateProps(dcnmClient, d, &networkProfile)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 	if arp, ok := d.GetOk("arp_supp_flag"); ok {
 		networkProfile.ARPSuppFlag = arp.(bool)
@@ -635,7 +648,7 @@ func resourceDCNMNetworkCreate(d *schema.ResourceData, m interface{}) error {
 
 	if mcast, ok := d.GetOk("mcast_group"); ok {
 		if fabricType == "MFD" {
-			return fmt.Errorf("mcast_group is not allowed if fabric type is %s", fabricType)
+			return diag.Errorf("mcast_group is not allowed if fabric type is %s", fabricType)
 		}
 		networkProfile.McastGroup = mcast.(string)
 	} else {
@@ -655,36 +668,6 @@ func resourceDCNMNetworkCreate(d *schema.ResourceData, m interface{}) error {
 			}
 		}
 	}
-	if dhcp1, ok := d.GetOk("dhcp_1"); ok {
-		networkProfile.DHCPServer1 = dhcp1.(string)
-	} else {
-		networkProfile.DHCPServer1 = ""
-	}
-	if dhcp2, ok := d.GetOk("dhcp_2"); ok {
-		networkProfile.DHCPServer2 = dhcp2.(string)
-	} else {
-		networkProfile.DHCPServer2 = ""
-	}
-	if dhcp3, ok := d.GetOk("dhcp_3"); ok {
-		networkProfile.DHCPServer3 = dhcp3.(string)
-	} else {
-		networkProfile.DHCPServer3 = ""
-	}
-	if dhcpvrf, ok := d.GetOk("dhcp_vrf"); ok {
-		networkProfile.DHCPServerVRF = dhcpvrf.(string)
-	} else {
-		networkProfile.DHCPServerVRF = ""
-	}
-	if dhcpvrf2, ok := d.GetOk("dhcp_vrf_2"); ok {
-		networkProfile.DHCPServerVRF2 = dhcpvrf2.(string)
-	} else {
-		networkProfile.DHCPServerVRF2 = ""
-	}
-	if dhcpvrf3, ok := d.GetOk("dhcp_vrf_3"); ok {
-		networkProfile.DHCPServerVRF3 = dhcpvrf3.(string)
-	} else {
-		networkProfile.DHCPServerVRF3 = ""
-	}
 	if loopback, ok := d.GetOk("loopback_id"); ok {
 		networkProfile.LookbackID = strconv.Itoa(loopback.(int))
 	} else {
@@ -723,124 +706,221 @@ func resourceDCNMNetworkCreate(d *schema.ResourceData, m interface{}) error {
 
 	configStr, err := json.Marshal(networkProfile)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	network.Config, err = mergeTemplateProps(configStr, templateProps)
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	network.Config = string(configStr)
 
-	durl := fmt.Sprintf("/rest/top-down/fabrics/%s/networks", fabricName)
-	_, err = dcnmClient.Save(durl, &network)
+	if existing != nil {
+		changes, changesErr := networkAdoptChanges(&network, existing)
+		if changesErr != nil {
+			return diag.FromErr(changesErr)
+		}
+		if len(changes) == 0 {
+			log.Printf("[INFO] Adopting existing network %s of fabric %s with network ID %s, its settings match the configuration", name, fabricName, segID)
+		} else {
+			log.Printf("[INFO] Adopting existing network %s of fabric %s with network ID %s and VLAN %s, updating %s", name, fabricName, segID, networkProfile.Vlan, strings.Join(changes, ", "))
+			durl := fmt.Sprintf("/rest/top-down/fabrics/%s/networks/%s", fabricName, name)
+			_, err = dcnmClient.Update(durl, &network)
+		}
+	} else {
+		durl := fmt.Sprintf("/rest/top-down/fabrics/%s/networks", fabricName)
+		_, err = dcnmClient.Save(durl, &network)
+	}
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(name)
 
 	//Network Deployment
 	if deploy, ok := d.GetOk("deploy"); ok && deploy.(bool) == true {
-		if _, ok := d.GetOk("attachments"); ok {
-			attachList := make([]map[string]interface{}, 0, 1)
-			for _, val := range d.Get("attachments").(*schema.Set).List() {
-				attachment := val.(map[string]interface{})
+		deployed := false
+		if existing != nil {
+			states, err := getNetworkAttachStates(dcnmClient, fabricName, name)
+			deployed = err == nil && attachmentsMatch(d.Get("attachments").(*schema.Set).List(), states)
+		}
+		if deployed {
+			log.Printf("[INFO] Existing network %s is already deployed as configured", name)
+		} else if err = attachNetwork(dcnmClient, d, &network, networkProfile.Vlan); err != nil {
+			return networkCreateFailure(d, m, err, reservations, existing != nil)
+		}
+	}
 
-				attachMap := make(map[string]interface{})
+	log.Println("[DEBUG] End of Create method ", d.Id())
+	return diag.FromErr(resourceDCNMNetworkRead(d, m))
+}
 
-				durl := fmt.Sprintf("/rest/control/switches/%s/fabric-name", attachment["serial_number"].(string))
-				cont, err := dcnmClient.GetviaURL(durl)
-				if err != nil {
-					return err
-				}
-				attachmentFabricName := stripQuotes(cont.S("fabricName").String())
+// networkAdoptChanges returns the settings of an existing network which
+// differ from the network to be adopted, template config keys are prefixed
+// with networkTemplateConfig.
+func networkAdoptChanges(network *models.Network, existing *container.Container) ([]string, error) {
+	networkMap, err := network.ToMap()
+	if err != nil {
+		return nil, err
+	}
+	delete(networkMap, "networkTemplateConfig")
+	changes := settingsChanges(networkMap, existing)
 
-				attachMap["fabric"] = attachmentFabricName
-				attachMap["networkName"] = network.Name
-				attachMap["deployment"] = attachment["attach"].(bool)
-				attachMap["serialNumber"] = attachment["serial_number"].(string)
+	desired := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(network.Config), &desired); err != nil {
+		return nil, err
+	}
+	remote, err := getTemplateConfig(existing, "networkTemplateConfig")
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range settingsChanges(desired, remote) {
+		changes = append(changes, "networkTemplateConfig."+key)
+	}
+	return changes, nil
+}
 
-				if attachment["vlan_id"].(int) != 0 {
-					attachMap["vlan"] = attachment["vlan_id"].(int)
-				} else {
-					attachMap["vlan"] = networkProfile.Vlan
-				}
-				if attachment["switch_ports"] != nil {
-					attachMap["switchPorts"] = listToString(attachment["switch_ports"])
-				} else {
-					attachMap["switchPorts"] = ""
-				}
+// attachNetwork attaches the network to the switches of the attachments and
+// deploys it.
+func attachNetwork(dcnmClient *client.Client, d *schema.ResourceData, network *models.Network, vlan string) error {
+	if err := saveNetworkAttachments(dcnmClient, d, network, vlan); err != nil {
+		return err
+	}
+	return deployNetwork(dcnmClient, d, network)
+}
 
-				if attachment["dot1_qvlan"] != nil {
-					attachMap["dot1QVlan"] = attachment["dot1_qvlan"].(int)
-				}
+// saveNetworkAttachments attaches or detaches the network to/from the
+// switches of the attachments. Only the switch ports added or removed since
+// the last apply are sent.
+func saveNetworkAttachments(dcnmClient *client.Client, d *schema.ResourceData, network *models.Network, vlan string) error {
+	oldAttachments, newAttachments := d.GetChange("attachments")
+	attachList := make([]map[string]interface{}, 0, 1)
+	for _, val := range d.Get("attachments").(*schema.Set).List() {
+		attachment := val.(map[string]interface{})
 
-				if attachment["untagged"] != nil {
-					attachMap["untagged"] = attachment["untagged"].(bool)
-				}
+		attachMap := make(map[string]interface{})
 
-				if attachment["free_form_config"] != nil {
-					attachMap["freeformConfig"] = attachment["free_form_config"].(string)
-				} else {
-					attachMap["freeformConfig"] = ""
-				}
+		durl := fmt.Sprintf("/rest/control/switches/%s/fabric-name", attachment["serial_number"].(string))
+		cont, err := dcnmClient.GetviaURL(durl)
+		if err != nil {
+			return err
+		}
+		attachmentFabricName := stripQuotes(cont.S("fabricName").String())
 
-				if attachment["extension_values"] != nil {
-					attachMap["extensionValues"] = attachment["extension_values"].(string)
-				}
+		attachMap["fabric"] = attachmentFabricName
+		attachMap["networkName"] = network.Name
+		attachMap["deployment"] = attachment["attach"].(bool)
+		attachMap["serialNumber"] = attachment["serial_number"].(string)
 
-				if attachment["instanceValues"] != nil {
-					attachMap["instanceValues"] = attachment["instance_values"].(string)
-				}
+		if attachment["vlan_id"].(int) != 0 {
+			attachMap["vlan"] = attachment["vlan_id"].(int)
+		} else {
+			attachMap["vlan"] = vlan
+		}
+		sPorts, dsPorts := findDiffForPorts(oldAttachments.(*schema.Set).List(), newAttachments.(*schema.Set).List(), attachMap["serialNumber"].(string))
+		if len(sPorts.([]interface{})) > 0 {
+			attachMap["switchPorts"] = listToString(sPorts)
+		} else {
+			attachMap["switchPorts"] = ""
+		}
+		if len(dsPorts.([]interface{})) > 0 {
+			attachMap["detachSwitchPorts"] = listToString(dsPorts)
+		} else {
+			attachMap["detachSwitchPorts"] = ""
+		}
 
-				attachList = append(attachList, attachMap)
-			}
+		if attachment["dot1_qvlan"] != nil {
+			attachMap["dot1QVlan"] = attachment["dot1_qvlan"].(int)
+		}
 
-			networkAttach := models.NewNetworkAttachment(network.Name, attachList)
-			durl := fmt.Sprintf("/rest/top-down/fabrics/%s/networks/attachments", network.Fabric)
-			cont, err := dcnmClient.SaveForAttachment(durl, networkAttach)
-			if err != nil {
-				d.Set("deploy", false)
-				d.Set("attachments", make([]interface{}, 0, 1))
-				return fmt.Errorf("Network record is created but not deployed yet. Error while attachment : %s", err)
-			}
+		if attachment["untagged"] != nil {
+			attachMap["untagged"] = attachment["untagged"].(bool)
+		}
 
-			// Network Deployment
-			for _, v := range cont.Data().(map[string]interface{}) {
-				if v != "SUCCESS" && v != "SUCCESS Peer attach Response -  SUCCESS" {
-					return fmt.Errorf("Network record is created but not deployed yet. Error while attachment : %s", v)
-				}
-			}
+		if attachment["free_form_config"] != nil {
+			attachMap["freeformConfig"] = attachment["free_form_config"].(string)
+		} else {
+			attachMap["freeformConfig"] = ""
+		}
 
-			durl = fmt.Sprintf("/rest/top-down/fabrics/%s/networks/%s/deploy", network.Fabric, network.Name)
-			_, err = dcnmClient.SaveAndDeploy(durl)
-			if err != nil {
-				d.Set("deploy", false)
-			}
+		if attachment["extension_values"] != nil {
+			attachMap["extensionValues"] = attachment["extension_values"].(string)
+		}
 
-			deployTFlag := false
-			deployTimeout := d.Get("deploy_timeout").(int)
-			for j := 0; j < (deployTimeout / 5); j++ {
-				deployFlag, err := getNetworkDeploymentStatus(dcnmClient, fabricName, name)
-				if err != nil {
-					return err
-				}
+		if attachment["instanceValues"] != nil {
+			attachMap["instanceValues"] = attachment["instance_values"].(string)
+		}
 
-				if !deployFlag {
-					time.Sleep(5 * time.Second)
-				} else {
-					deployTFlag = true
-					break
-				}
-			}
-			if !deployTFlag {
-				return fmt.Errorf("Network record is created but not deployed yet. deployment timeout occured")
-			}
+		attachList = append(attachList, attachMap)
+	}
 
-		} else {
-			d.Set("deploy", false)
-			d.Set("attachments", make([]interface{}, 0, 1))
-			return fmt.Errorf("Network record is created but not deployed yet. Either make deploy=false or provide attachments")
+	networkAttach := models.NewNetworkAttachment(network.Name, attachList)
+	durl := fmt.Sprintf("/rest/top-down/fabrics/%s/networks/attachments", network.Fabric)
+	cont, err := dcnmClient.SaveForAttachment(durl, networkAttach)
+	if err != nil {
+		return fmt.Errorf("Error while attachment : %s", err)
+	}
+
+	for _, v := range cont.Data().(map[string]interface{}) {
+		if v != "SUCCESS" && v != "SUCCESS Peer attach Response -  SUCCESS" {
+			return fmt.Errorf("Error while attachment : %s", v)
 		}
 	}
+	return nil
+}
 
-	log.Println("[DEBUG] End of Create method ", d.Id())
-	return resourceDCNMNetworkRead(d, m)
+// deployNetwork deploys the network to the switches it is attached to and
+// waits for the deployment.
+func deployNetwork(dcnmClient *client.Client, d *schema.ResourceData, network *models.Network) error {
+	states, err := getNetworkAttachStates(dcnmClient, network.Fabric, network.Name)
+	if err != nil {
+		return err
+	}
+	stale := staleFailures(states)
+
+	durl := fmt.Sprintf("/rest/top-down/fabrics/%s/networks/%s/deploy", network.Fabric, network.Name)
+	_, err = dcnmClient.SaveAndDeploy(durl)
+	if err != nil {
+		return err
+	}
+
+	return waitForNetworkDeployment(dcnmClient, network.Fabric, network.Name, stale, d.Get("deploy_timeout").(int))
+}
+
+// networkCreateFailure handles a failed attachment or deployment of a newly
+// created network as set by on_create_failure. A rollback detaches and
+// deletes the network again and releases the IDs reserved for it. Otherwise
+// the network is kept with a warning, attached_switches records the switches
+// it actually got attached to.
+func networkCreateFailure(d *schema.ResourceData, m interface{}, createErr error, reservations []resourceReservation, adopted bool) diag.Diagnostics {
+	dcnmClient := m.(*client.Client)
+	name := d.Id()
+	fabricName := d.Get("fabric_name").(string)
+
+	// an adopted network existed before the apply, so it is never rolled back
+	if !adopted && d.Get("on_create_failure").(string) == "rollback" {
+		log.Printf("[DEBUG] Rolling back network %s after failure: %s", name, createErr)
+		if err := resourceDCNMNetworkDelete(d, m); err != nil {
+			return diag.Errorf("network %s failed to deploy: %s. Rollback failed: %s", name, createErr, err)
+		}
+		if err := releaseReservations(dcnmClient, fabricName, reservations); err != nil {
+			return diag.Errorf("network %s failed to deploy and is deleted: %s. Releasing its IDs failed: %s", name, createErr, err)
+		}
+		return diag.Errorf("network %s failed to deploy and is rolled back: %s", name, createErr)
+	}
+
+	action := "created"
+	if adopted {
+		action = "adopted"
+	}
+	if err := resourceDCNMNetworkRead(d, m); err != nil {
+		return diag.Errorf("network %s is %s but failed to deploy: %s. Reading its state failed: %s", name, action, createErr, err)
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("network %s is %s but failed to deploy", name, action),
+			Detail:   fmt.Sprintf("%s. The switches the network is attached to are listed in attached_switches, the next apply retries the deployment.", createErr),
+		},
+	}
 }
 
 func resourceDCNMNetworkUpdate(d *schema.ResourceData, m interface{}) error {
@@ -915,17 +995,9 @@ func resourceDCNMNetworkUpdate(d *schema.ResourceData, m interface{}) error {
 	} else {
 		networkProfile.MTU = ""
 	}
-	if secgw1, ok := d.GetOk("secondary_gw_1"); ok {
-		networkProfile.SecondaryGate1 = secgw1.(string)
-	}
-	if secgw2, ok := d.GetOk("secondary_gw_2"); ok {
-		networkProfile.SecondaryGate2 = secgw2.(string)
-	}
-	if secgw3, ok := d.GetOk("secondary_gw_3"); ok {
-		networkProfile.SecondaryGate3 = secgw3.(string)
-	}
-	if secgw4, ok := d.GetOk("secondary_gw_4"); ok {
-		networkProfile.SecondaryGate4 = secgw4.(string)
+	templateProps, err := networkTemplateProps(dcnmClient, d, &networkProfile)
+	if err != nil {
+		return err
 	}
 	if arp, ok := d.GetOk("arp_supp_flag"); ok {
 		networkProfile.ARPSuppFlag = arp.(bool)
@@ -960,36 +1032,6 @@ func resourceDCNMNetworkUpdate(d *schema.ResourceData, m interface{}) error {
 			}
 		}
 	}
-	if dhcp1, ok := d.GetOk("dhcp_1"); ok {
-		networkProfile.DHCPServer1 = dhcp1.(string)
-	} else {
-		networkProfile.DHCPServer1 = ""
-	}
-	if dhcp2, ok := d.GetOk("dhcp_2"); ok {
-		networkProfile.DHCPServer2 = dhcp2.(string)
-	} else {
-		networkProfile.DHCPServer2 = ""
-	}
-	if dhcp3, ok := d.GetOk("dhcp_3"); ok {
-		networkProfile.DHCPServer3 = dhcp3.(string)
-	} else {
-		networkProfile.DHCPServer3 = ""
-	}
-	if dhcpvrf, ok := d.GetOk("dhcp_vrf"); ok {
-		networkProfile.DHCPServerVRF = dhcpvrf.(string)
-	} else {
-		networkProfile.DHCPServerVRF = ""
-	}
-	if dhcpvrf2, ok := d.GetOk("dhcp_vrf_2"); ok {
-		networkProfile.DHCPServerVRF2 = dhcpvrf2.(string)
-	} else {
-		networkProfile.DHCPServerVRF2 = ""
-	}
-	if dhcpvrf3, ok := d.GetOk("dhcp_vrf_3"); ok {
-		networkProfile.DHCPServerVRF3 = dhcpvrf3.(string)
-	} else {
-		networkProfile.DHCPServerVRF3 = ""
-	}
 	if loopback, ok := d.GetOk("loopback_id"); ok {
 		networkProfile.LookbackID = strconv.Itoa(loopback.(int))
 	} else {
@@ -1030,7 +1072,10 @@ func resourceDCNMNetworkUpdate(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	network.Config = string(configStr)
+	network.Config, err = mergeTemplateProps(configStr, templateProps)
+	if err != nil {
+		return err
+	}
 
 	dn := d.Id()
 	durl := fmt.Sprintf("/rest/top-down/fabrics/%s/networks/%s", fabricName, dn)
@@ -1047,105 +1092,14 @@ func resourceDCNMNetworkUpdate(d *schema.ResourceData, m interface{}) error {
 
 	if deploy, ok := d.GetOk("deploy"); ok && deploy.(bool) == true {
 		if _, ok := d.GetOk("attachments"); ok {
-			attachList := make([]map[string]interface{}, 0, 1)
-			for _, val := range d.Get("attachments").(*schema.Set).List() {
-				attachment := val.(map[string]interface{})
-
-				attachMap := make(map[string]interface{})
-				durl := fmt.Sprintf("/rest/control/switches/%s/fabric-name", attachment["serial_number"].(string))
-				cont, err := dcnmClient.GetviaURL(durl)
-				if err != nil {
-					return err
-				}
-				attachmentFabricName := stripQuotes(cont.S("fabricName").String())
-				attachMap["fabric"] = attachmentFabricName
-				attachMap["networkName"] = network.Name
-				attachMap["deployment"] = attachment["attach"].(bool)
-				attachMap["serialNumber"] = attachment["serial_number"].(string)
-
-				if attachment["vlan_id"].(int) != 0 {
-					attachMap["vlan"] = attachment["vlan_id"].(int)
-				} else {
-					attachMap["vlan"] = networkProfile.Vlan
-				}
-
-				oldAttachments, newAttachments := d.GetChange("attachments")
-				sPorts, dsPorts := findDiffForPorts(oldAttachments.(*schema.Set).List(), newAttachments.(*schema.Set).List(), attachMap["serialNumber"].(string))
-				if len(sPorts.([]interface{})) > 0 {
-					attachMap["switchPorts"] = listToString(sPorts)
-				} else {
-					attachMap["switchPorts"] = ""
-				}
-
-				if len(dsPorts.([]interface{})) > 0 {
-					attachMap["detachSwitchPorts"] = listToString(dsPorts)
-				} else {
-					attachMap["detachSwitchPorts"] = ""
-				}
-
-				if attachment["dot1_qvlan"] != nil {
-					attachMap["dot1QVlan"] = attachment["dot1_qvlan"].(int)
-				}
-
-				if attachment["untagged"] != nil {
-					attachMap["untagged"] = attachment["untagged"].(bool)
-				}
-
-				if attachment["free_form_config"] != nil {
-					attachMap["freeformConfig"] = attachment["free_form_config"].(string)
-				}
-
-				if attachment["extension_values"] != nil {
-					attachMap["extensionValues"] = attachment["extension_values"].(string)
-				}
-
-				if attachment["instanceValues"] != nil {
-					attachMap["instanceValues"] = attachment["instance_values"].(string)
-				}
-
-				attachList = append(attachList, attachMap)
-			}
-
-			networkAttach := models.NewNetworkAttachment(network.Name, attachList)
-			durl := fmt.Sprintf("/rest/top-down/fabrics/%s/networks/attachments", network.Fabric)
-			cont, err := dcnmClient.SaveForAttachment(durl, networkAttach)
-			if err != nil {
+			if err := saveNetworkAttachments(dcnmClient, d, &network, networkProfile.Vlan); err != nil {
 				d.Set("deploy", false)
-				d.Set("attachments", make([]interface{}, 0, 1))
-				return fmt.Errorf("Network record is updated but not deployed yet. Error while attachment : %s", err)
-			}
-
-			// Network Deployment
-			for _, v := range cont.Data().(map[string]interface{}) {
-				if v != "SUCCESS" && v != "SUCCESS Peer attach Response -  SUCCESS" {
-					return fmt.Errorf("Network record is updated but not deployed yet. Error while attachment : %s", v)
-				}
+				return fmt.Errorf("Network record is updated but not deployed yet. %s", err)
 			}
 
-			durl = fmt.Sprintf("/rest/top-down/fabrics/%s/networks/%s/deploy", network.Fabric, network.Name)
-			_, err = dcnmClient.SaveAndDeploy(durl)
-			if err != nil {
+			if err := deployNetwork(dcnmClient, d, &network); err != nil {
 				d.Set("deploy", false)
-			}
-
-			deployTFlag := false
-			deployTimeout := d.Get("deploy_timeout").(int)
-			for j := 0; j < (deployTimeout / 5); j++ {
-				deployFlag, err := getNetworkDeploymentStatus(dcnmClient, fabricName, name)
-				if err != nil {
-					return err
-				}
-
-				if !deployFlag {
-					time.Sleep(5 * time.Second)
-				} else {
-					deployTFlag = true
-					break
-				}
-			}
-			if !deployTFlag {
-				d.Set("deploy", false)
-				return fmt.Errorf("Network record is updated and deployment is initialised, but deployment timeout occured before completion of the deployment process")
+				return fmt.Errorf("Network record is updated and deployment is initialised, but %s", err)
 			}
 
 		} else {
@@ -1174,6 +1128,12 @@ func resourceDCNMNetworkRead(d *schema.ResourceData, m interface{}) error {
 
 	setNetworkAttributes(d, cont)
 
+	if props, ok := d.GetOk("template_props"); ok {
+		if configCont, err := getTemplateConfig(cont, "networkTemplateConfig"); err == nil {
+			d.Set("template_props", readTemplateProps(configCont, props.(map[string]interface{})))
+		}
+	}
+
 	deployed, err := checkNetworkDeploy(dcnmClient, fabricName, dn)
 	if err != nil {
 		d.Set("deploy", false)
@@ -1181,20 +1141,24 @@ func resourceDCNMNetworkRead(d *schema.ResourceData, m interface{}) error {
 	}
 	d.Set("deploy", deployed)
 
+	durl := fmt.Sprintf("/rest/top-down/fabrics/%s/networks/%s/attachments", fabricName, dn)
+	attachCont, err := dcnmClient.GetviaURL(durl)
+	if err != nil {
+		return err
+	}
+	states := parseAttachmentStates(attachCont.Children())
+	d.Set("attached_switches", attachedSwitches(states))
+
 	if attaches, ok := d.GetOk("attachments"); ok {
 		attachGet := make([]interface{}, 0, 1)
 
-		durl := fmt.Sprintf("/rest/top-down/fabrics/%s/networks/%s/attachments", fabricName, dn)
-		cont, err := dcnmClient.GetviaURL(durl)
-		if err != nil {
-			return err
-		}
-
 		for _, val := range attaches.(*schema.Set).List() {
 			attachMap := val.(map[string]interface{})
 			serialNum := attachMap["serial_number"].(string)
 
-			attachStatus, ports, vlan, err := getNetworkSwitchAttachStatus(cont, serialNum)
+			setAttachmentState(attachMap, states[serialNum])
+
+			attachStatus, ports, vlan, err := getNetworkSwitchAttachStatus(attachCont, serialNum)
 			if err == nil {
 				attachMap["attach"] = attachStatus
 				if attachMap["vlan_id"].(int) != 0 {
@@ -1274,29 +1238,21 @@ func resourceDCNMNetworkDelete(d *schema.ResourceData, m interface{}) error {
 					return fmt.Errorf("Error while detachment : %s", v)
 				}
 			}
+			states, err := getNetworkAttachStates(dcnmClient, fabricName, dn)
+			if err != nil {
+				return err
+			}
+			stale := staleFailures(states)
+
 			durl = fmt.Sprintf("/rest/top-down/fabrics/%s/networks/%s/deploy", fabricName, dn)
 			_, err = dcnmClient.SaveAndDeploy(durl)
 			if err != nil {
 				d.Set("deploy", false)
 			}
 
-			deployTFlag := false
-			deployTimeout := d.Get("deploy_timeout").(int)
-			for j := 0; j < (deployTimeout / 5); j++ {
-				deployFlag, err := getNetworkDeploymentStatus(dcnmClient, fabricName, dn)
-				if err != nil {
-					return err
-				}
-
-				if !deployFlag {
-					time.Sleep(5 * time.Second)
-				} else {
-					deployTFlag = true
-					break
-				}
-			}
-			if !deployTFlag {
-				return fmt.Errorf("Network record can not be deleted. deployment timeout occured")
+			err = waitForNetworkDeployment(dcnmClient, fabricName, dn, stale, d.Get("deploy_timeout").(int))
+			if err != nil {
+				return fmt.Errorf("Network record can not be deleted. %s", err)
 			}
 		}
 	}
@@ -1313,21 +1269,123 @@ func resourceDCNMNetworkDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func checkNetworkDeploy(client *client.Client, fabricName, dn string) (bool, error) {
-	durl := fmt.Sprintf("/rest/top-down/fabrics/%s/networks/%s/attachments", fabricName, dn)
-	cont, err := client.GetviaURL(durl)
-	if err != nil {
-		return false, err
+// networkTemplateProps sets the secondary gateways and the DHCP relays of the
+// template config. The template has fields for three relays only, so all of
+// them are also passed as dhcpServers for the NDFC 12 templates declaring it.
+// More relays are rejected for templates without dhcpServers. The returned
+// props include template_props.
+func networkTemplateProps(dcnmClient *client.Client, d *schema.ResourceData, profile *models.NetworkProfileConfig) (map[string]interface{}, error) {
+	gatewayFields := []*string{&profile.SecondaryGate1, &profile.SecondaryGate2, &profile.SecondaryGate3, &profile.SecondaryGate4}
+	for i, gateway := range interfaceToStrList(d.Get("secondary_gateways")) {
+		*gatewayFields[i] = gateway
 	}
 
-	flag := false
-	for i := 0; i < len(cont.Data().([]interface{})); i++ {
-		if stripQuotes(cont.Index(0).S("lanAttachState").String()) == "DEPLOYED" {
-			flag = true
-			break
+	props := make(map[string]interface{})
+
+	relayFields := [][2]*string{
+		{&profile.DHCPServer1, &profile.DHCPServerVRF},
+		{&profile.DHCPServer2, &profile.DHCPServerVRF2},
+		{&profile.DHCPServer3, &profile.DHCPServerVRF3},
+	}
+	relays := d.Get("dhcp_relay").([]interface{})
+
+	hasServers := false
+	if len(relays) > 0 || d.HasChange("dhcp_relay") {
+		template := d.Get("template").(string)
+		cont, err := getTemplate(dcnmClient, template)
+		if err != nil {
+			return nil, getErrorFromContainer(cont, err)
+		}
+		content, _ := cont.S("content").Data().(string)
+		hasServers, err = templateHasParameter(content, "dhcpServers")
+		if err != nil {
+			return nil, fmt.Errorf("error at parsing template %s: %s", template, err)
+		}
+		if !hasServers && len(relays) > len(relayFields) {
+			return nil, fmt.Errorf("template %s supports %d DHCP relays only, %d are configured", template, len(relayFields), len(relays))
 		}
 	}
-	return flag, nil
+
+	servers := make([]map[string]interface{}, 0)
+	for i, val := range relays {
+		relay := val.(map[string]interface{})
+		if i < len(relayFields) {
+			*relayFields[i][0] = relay["server_address"].(string)
+			*relayFields[i][1] = relay["vrf_name"].(string)
+		}
+		servers = append(servers, map[string]interface{}{
+			"srvrAddr": relay["server_address"].(string),
+			"srvrVrf":  relay["vrf_name"].(string),
+		})
+	}
+	if hasServers {
+		serversStr, err := json.Marshal(map[string]interface{}{
+			"dhcpServers": servers,
+		})
+		if err != nil {
+			return nil, err
+		}
+		props["dhcpServers"] = string(serversStr)
+	}
+
+	for key, value := range d.Get("template_props").(map[string]interface{}) {
+		props[key] = value
+	}
+	return props, nil
+}
+
+func getNetworkSecondaryGateways(config *container.Container) []interface{} {
+	gateways := make([]interface{}, 0)
+	for i := 1; i <= 4; i++ {
+		if gateway := models.G(config, fmt.Sprintf("secondaryGW%d", i)); gateway != "" && gateway != "null" {
+			gateways = append(gateways, gateway)
+		}
+	}
+	return gateways
+}
+
+// getNetworkDHCPRelays returns the DHCP relays of the template config, from
+// dhcpServers when set and from the three relay fields otherwise.
+func getNetworkDHCPRelays(config *container.Container) []interface{} {
+	relays := make([]interface{}, 0)
+
+	if serversStr, ok := config.S("dhcpServers").Data().(string); ok && serversStr != "" {
+		if servers, err := container.ParseJSON([]byte(serversStr)); err == nil {
+			for _, server := range servers.S("dhcpServers").Children() {
+				relays = append(relays, map[string]interface{}{
+					"server_address": models.G(server, "srvrAddr"),
+					"vrf_name":       models.G(server, "srvrVrf"),
+				})
+			}
+			return relays
+		}
+	}
+
+	for _, keys := range [][2]string{{"dhcpServerAddr1", "vrfDhcp"}, {"dhcpServerAddr2", "vrfDhcp2"}, {"dhcpServerAddr3", "vrfDhcp3"}} {
+		server := models.G(config, keys[0])
+		if server == "" || server == "null" {
+			continue
+		}
+		vrf := models.G(config, keys[1])
+		if vrf == "null" {
+			vrf = ""
+		}
+		relays = append(relays, map[string]interface{}{
+			"server_address": server,
+			"vrf_name":       vrf,
+		})
+	}
+	return relays
+}
+
+// checkNetworkDeploy returns whether the network is deployed to all the
+// switches it is attached to.
+func checkNetworkDeploy(client *client.Client, fabricName, dn string) (bool, error) {
+	states, err := getNetworkAttachStates(client, fabricName, dn)
+	if err != nil {
+		return false, err
+	}
+	return attachmentsDeployed(states), nil
 }
 
 func getNetworkSwitchAttachStatus(cont *container.Container, serial string) (bool, []string, int, error) {
@@ -1417,6 +1475,41 @@ func getNetworkDeploymentStatus(client *client.Client, fabricName, vrfName strin
 	return flag, nil
 }
 
+func getNetworkAttachStates(client *client.Client, fabricName, networkName string) (map[string]attachmentState, error) {
+	durl := fmt.Sprintf("/rest/top-down/fabrics/%s/networks/%s/attachments", fabricName, networkName)
+	cont, err := client.GetviaURL(durl)
+	if err != nil {
+		return nil, err
+	}
+	return parseAttachmentStates(cont.Children()), nil
+}
+
+// waitForNetworkDeployment waits for the switches of the network to be in
+// sync. It fails as soon as the deployment to any of the switches has failed,
+// FAILED states of stale taken before the deploy request are only reported
+// once they change.
+func waitForNetworkDeployment(client *client.Client, fabricName, networkName string, stale map[string]bool, deployTimeout int) error {
+	for j := 0; j < (deployTimeout / 5); j++ {
+		states, err := getNetworkAttachStates(client, fabricName, networkName)
+		if err != nil {
+			return err
+		}
+		if err := attachmentFailure(states, stale); err != nil {
+			return err
+		}
+
+		deployFlag, err := getNetworkDeploymentStatus(client, fabricName, networkName)
+		if err != nil {
+			return err
+		}
+		if deployFlag {
+			return nil
+		}
+		time.Sleep(5 * time.Second)
+	}
+	return fmt.Errorf("deployment timeout occured")
+}
+
 func getFabricType(dcnmClient *client.Client, fabricName string) (string, error) {
 	cont, err := dcnmClient.GetviaURL("/rest/control/fabrics/" + fabricName)
 	if err != nil {
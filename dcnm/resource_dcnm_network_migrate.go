@@ -0,0 +1,361 @@
+package dcnm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceDCNMNetworkV0 is the schema of version 0 of dcnm_network, with
+// the flat DHCP relay and secondary gateway attributes.
+func resourceDCNMNetworkV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"fabric_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"display_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"network_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"template": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Default_Network_Universal",
+			},
+
+			"extension_template": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Default_Network_Extension_Universal",
+			},
+
+			"vrf_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "NA",
+			},
+
+			"l2_only_flag": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+
+			"vlan_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"vlan_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"ipv4_gateway": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"ipv6_gateway": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"mtu": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"secondary_gw_1": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"secondary_gw_2": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"secondary_gw_3": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"secondary_gw_4": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"arp_supp_flag": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+
+			"ir_enable_flag": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+
+			"mcast_group": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"dhcp_1": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"dhcp_2": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"dhcp_3": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"dhcp_vrf": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"dhcp_vrf_2": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"dhcp_vrf_3": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"loopback_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"tag": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"trm_enable_flag": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+
+			"rt_both_flag": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+
+			"l3_gateway_flag": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+
+			"service_template": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"source": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"template_props": &schema.Schema{
+				Type:         schema.TypeMap,
+				Optional:     true,
+				ValidateFunc: validateTemplateProps("networkName", "segmentId"),
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"deploy": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"deploy_timeout": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  300,
+			},
+
+			"netflow_flag": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"svi_netflow_monitor": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"vlan_netflow_monitor": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"nve_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  1,
+			},
+
+			"attachments": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"serial_number": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"vlan_id": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+						},
+
+						"dot1_qvlan": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+						},
+
+						"attach": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+
+						"switch_ports": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+
+						"untagged": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Computed: true,
+						},
+
+						"free_form_config": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+
+						"extension_values": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+
+						"instance_values": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceDCNMNetworkStateUpgradeV0 moves dhcp_1..3/dhcp_vrf..3 to the
+// dhcp_relay list and secondary_gw_1..4 to the secondary_gateways list. Empty
+// slots are dropped, like Read does for the template fields, so a relay set
+// in dhcp_2 only becomes the first relay. The relays and gateways are written
+// to the first template fields on the next update of the network.
+func resourceDCNMNetworkStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return nil, nil
+	}
+
+	relays := make([]interface{}, 0)
+	for _, keys := range [][2]string{{"dhcp_1", "dhcp_vrf"}, {"dhcp_2", "dhcp_vrf_2"}, {"dhcp_3", "dhcp_vrf_3"}} {
+		server, _ := rawState[keys[0]].(string)
+		vrf, _ := rawState[keys[1]].(string)
+		if server != "" {
+			relays = append(relays, map[string]interface{}{
+				"server_address": server,
+				"vrf_name":       vrf,
+			})
+		}
+		delete(rawState, keys[0])
+		delete(rawState, keys[1])
+	}
+	rawState["dhcp_relay"] = relays
+
+	gateways := make([]interface{}, 0)
+	for i := 1; i <= 4; i++ {
+		key := fmt.Sprintf("secondary_gw_%d", i)
+		if gateway, _ := rawState[key].(string); gateway != "" {
+			gateways = append(gateways, gateway)
+		}
+		delete(rawState, key)
+	}
+	rawState["secondary_gateways"] = gateways
+
+	return rawState, nil
+}
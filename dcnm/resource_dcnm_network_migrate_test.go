@@ -0,0 +1,74 @@
+package dcnm
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestResourceDCNMNetworkStateUpgradeV0(t *testing.T) {
+	rawState := map[string]interface{}{
+		"name":           "net1",
+		"dhcp_1":         "1.2.3.4",
+		"dhcp_2":         "",
+		"dhcp_3":         "1.2.3.6",
+		"dhcp_vrf":       "VRF1012",
+		"dhcp_vrf_2":     "",
+		"dhcp_vrf_3":     "VRF1014",
+		"secondary_gw_1": "192.0.3.1/24",
+		"secondary_gw_2": "192.0.4.1/24",
+		"secondary_gw_3": "",
+		"secondary_gw_4": "",
+	}
+	expected := map[string]interface{}{
+		"name": "net1",
+		"dhcp_relay": []interface{}{
+			map[string]interface{}{
+				"server_address": "1.2.3.4",
+				"vrf_name":       "VRF1012",
+			},
+			map[string]interface{}{
+				"server_address": "1.2.3.6",
+				"vrf_name":       "VRF1014",
+			},
+		},
+		"secondary_gateways": []interface{}{"192.0.3.1/24", "192.0.4.1/24"},
+	}
+
+	actual, err := resourceDCNMNetworkStateUpgradeV0(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatalf("error migrating state: %s", err)
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", expected, actual)
+	}
+}
+
+func TestResourceDCNMNetworkStateUpgradeV0EmptySlots(t *testing.T) {
+	rawState := map[string]interface{}{
+		"name":           "net1",
+		"dhcp_1":         "",
+		"dhcp_2":         "1.2.3.5",
+		"dhcp_vrf":       "",
+		"dhcp_vrf_2":     "VRF1013",
+		"secondary_gw_3": "192.0.5.1/24",
+	}
+	expected := map[string]interface{}{
+		"name": "net1",
+		"dhcp_relay": []interface{}{
+			map[string]interface{}{
+				"server_address": "1.2.3.5",
+				"vrf_name":       "VRF1013",
+			},
+		},
+		"secondary_gateways": []interface{}{"192.0.5.1/24"},
+	}
+
+	actual, err := resourceDCNMNetworkStateUpgradeV0(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatalf("error migrating state: %s", err)
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", expected, actual)
+	}
+}
@@ -2,6 +2,7 @@ package dcnm
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"testing"
 
@@ -62,6 +63,124 @@ func TestAccDCNMNetwork_Update(t *testing.T) {
 	})
 }
 
+func TestAccDCNMNetwork_CreateFailurePartial(t *testing.T) {
+	var network models.Network
+	var networkProfile models.NetworkProfileConfig
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactoriesInternal(&providerNetwork),
+		CheckDestroy:      testAccCheckDCNMNetworkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDCNMNetworkConfig_createFailure("partial"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMNetworkExists("dcnm_network.test", &network, &networkProfile),
+					resource.TestCheckResourceAttr("dcnm_network.test", "deploy", "false"),
+					resource.TestCheckResourceAttr("dcnm_network.test", "attached_switches.#", "0"),
+					// the state keeps the attach flag read back from the switch
+					resource.TestCheckTypeSetElemNestedAttrs("dcnm_network.test", "attachments.*", map[string]string{
+						"serial_number": "9EQ00OGQYV7",
+						"attach":        "false",
+					}),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// the next plan retries the failed deployment
+				Config:             testAccCheckDCNMNetworkConfig_createFailure("partial"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccDCNMNetwork_CreateFailureRollback(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactoriesInternal(&providerNetwork),
+		CheckDestroy:      testAccCheckDCNMNetworkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckDCNMNetworkConfig_createFailure("rollback"),
+				ExpectError: regexp.MustCompile("is rolled back"),
+			},
+		},
+	})
+}
+
+// testAccCheckDCNMNetworkConfig_createFailure attaches the network to a
+// switch which is not part of the fabric, so its deployment fails.
+func testAccCheckDCNMNetworkConfig_createFailure(mode string) string {
+	return fmt.Sprintf(`
+	resource "dcnm_network" "test" {
+		fabric_name       = "fab2"
+		name              = "import"
+		vrf_name          = "Test-vrf"
+		on_create_failure = "%s"
+		deploy            = true
+		attachments {
+			serial_number = "9EQ00OGQYV7"
+			attach        = true
+		}
+	}
+	`, mode)
+}
+
+func TestAccDCNMNetwork_Adopt(t *testing.T) {
+	var network models.Network
+	var networkProfile models.NetworkProfileConfig
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactoriesInternal(&providerNetwork),
+		CheckDestroy:      testAccCheckDCNMNetworkDestroy,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					testAccCreateUnmanaged(t, providerNetwork, resourceDCNMNetwork(), map[string]interface{}{
+						"fabric_name":  "fab2",
+						"name":         "import",
+						"display_name": "check",
+						"description":  "network created outside terraform",
+						"vrf_name":     "Test-vrf",
+						"vlan_id":      2301,
+						"vlan_name":    "vlan1",
+					})
+				},
+				Config: testAccCheckDCNMNetworkConfig_adopt("network adopt check"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMNetworkExists("dcnm_network.test", &network, &networkProfile),
+					testAccCheckDCNMNetworkAttributes("network adopt check", &network, &networkProfile),
+					resource.TestCheckResourceAttr("dcnm_network.test", "attached_switches.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDCNMNetworkConfig_adopt(desc string) string {
+	return fmt.Sprintf(`
+	resource "dcnm_network" "test" {
+		fabric_name     = "fab2"
+		name            = "import"
+		display_name    = "check"
+		description     = "%s"
+		vrf_name        = "Test-vrf"
+		vlan_id         = 2301
+		vlan_name       = "vlan1"
+		adopt_existing  = true
+		deploy          = true
+		attachments {
+			serial_number = "9EQ00OGQYV6"
+			vlan_id       = 2400
+			attach        = true
+		}
+	}
+	`, desc)
+}
+
 func testAccCheckDCNMNetworkConfig_basic(desc, deploy string) string {
 	return fmt.Sprintf(`
 	resource "dcnm_network" "test" {
@@ -159,7 +278,7 @@ func testAccCheckDCNMNetworkAttributes(desc string, network *models.Network, pro
 		}
 
 		if strconv.Itoa(2301) != (profile.Vlan) {
-			return fmt.Errorf("Bad Network VLAN %d", profile.Vlan)
+			return fmt.Errorf("Bad Network VLAN %s", profile.Vlan)
 		}
 
 		if "vlan1" != profile.VlanName {
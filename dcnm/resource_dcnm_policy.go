@@ -23,13 +23,15 @@ const MAX_RETRY_DEL int = 4
 
 var switchDeployMutexMap = make(map[string]*sync.Mutex, 0)
 var policyURLs = map[string]string{
-	"Create":        "/rest/control/policies",
-	"PolicyDeploy":  "/rest/control/policies/deploy",
-	"MarkDelete":    "/rest/control/policies/%s/mark-delete",
-	"IntentConfig":  "/rest/control/policies/%s/intent-config",
-	"Common":        "/rest/control/policies/%s",
-	"GetFabricName": "/rest/control/switches/%s/fabric-name",
-	"GetPolicy":     "/rest/control/policies/switches/%s?source=POLICY-%s",
+	"Create":         "/rest/control/policies",
+	"List":           "/rest/control/policies",
+	"PolicyDeploy":   "/rest/control/policies/deploy",
+	"MarkDelete":     "/rest/control/policies/%s/mark-delete",
+	"IntentConfig":   "/rest/control/policies/%s/intent-config",
+	"Common":         "/rest/control/policies/%s",
+	"GetFabricName":  "/rest/control/switches/%s/fabric-name",
+	"GetPolicy":      "/rest/control/policies/switches/%s?source=POLICY-%s",
+	"SwitchPolicies": "/rest/control/policies/switches/%s",
 }
 
 func resourceDCNMPolicy() *schema.Resource {
@@ -106,6 +108,11 @@ func resourceDCNMPolicy() *schema.Resource {
 				Optional: true,
 				Default:  60,
 			},
+			"adopt_existing": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"child_policies": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -189,6 +196,20 @@ func resourceDCNMPolicyCreate(ctx context.Context, d *schema.ResourceData, m int
 		policy.TemplateContentType = templateContentType.(string)
 	}
 
+	if d.Get("adopt_existing").(bool) {
+		cont, err := dcnmClient.GetviaURL(fmt.Sprintf(policyURLs["SwitchPolicies"], serialNumber))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		ids := existingPolicyIDs(cont.Children(), &policy)
+		if len(ids) > 1 {
+			return diag.Errorf("policies %s of switch %s all match the configuration, import the intended one instead", strings.Join(ids, ", "), serialNumber)
+		}
+		if len(ids) == 1 {
+			return adoptPolicy(ctx, d, m, &policy, cont.Children(), ids[0])
+		}
+	}
+
 	cont, err := dcnmClient.Save(policyURLs["Create"], &policy)
 	if err != nil {
 		return diag.FromErr(err)
@@ -209,6 +230,86 @@ func resourceDCNMPolicyCreate(ctx context.Context, d *schema.ResourceData, m int
 	return resourceDCNMPolicyRead(ctx, d, m)
 }
 
+// adoptPolicy takes over the existing policy id, it is only updated if its
+// settings differ from policy. Failures after the policy is found are
+// returned as warnings, so the adopted policy stays in the state instead of
+// being tainted.
+func adoptPolicy(ctx context.Context, d *schema.ResourceData, m interface{}, policy *models.Policy, policies []*container.Container, id string) diag.Diagnostics {
+	dcnmClient := m.(*client.Client)
+	d.SetId(id)
+
+	var existing *container.Container
+	for _, policyCont := range policies {
+		if models.G(policyCont, "id") == id {
+			existing = policyCont
+		}
+	}
+
+	var diags diag.Diagnostics
+	if changes := policyAdoptChanges(policy, existing); len(changes) > 0 {
+		log.Printf("[INFO] Adopting existing policy %s of switch %s with template %s, updating %s", id, policy.SerialNumber, policy.TemplateName, strings.Join(changes, ", "))
+		diags = resourceDCNMPolicyUpdate(ctx, d, m)
+	} else {
+		log.Printf("[INFO] Adopting existing policy %s of switch %s with template %s, its settings match the configuration", id, policy.SerialNumber, policy.TemplateName)
+		if deploy, ok := d.GetOk("deploy"); ok && deploy.(bool) {
+			err := deployPolicyWithTimeout(dcnmClient, POLICY_PREFIX+id, policy.SerialNumber, d.Get("deploy_timeout").(int))
+			if err != nil {
+				d.Set("deploy", false)
+				diags = diag.FromErr(err)
+			}
+		}
+	}
+	if !diags.HasError() {
+		return append(diags, resourceDCNMPolicyRead(ctx, d, m)...)
+	}
+
+	for i := range diags {
+		diags[i].Severity = diag.Warning
+		diags[i].Summary = fmt.Sprintf("policy %s is adopted but failed to apply the configuration: %s", id, diags[i].Summary)
+	}
+	return append(diags, resourceDCNMPolicyRead(ctx, d, m)...)
+}
+
+// policyAdoptChanges returns the settings of an existing policy which differ
+// from policy, template properties are prefixed with nvPairs.
+func policyAdoptChanges(policy *models.Policy, existing *container.Container) []string {
+	settings := make(map[string]interface{})
+	if policy.Description != "" {
+		settings["description"] = policy.Description
+	}
+	if policy.Priority != "" {
+		settings["priority"] = policy.Priority
+	}
+	changes := settingsChanges(settings, existing)
+	nvPairs, _ := policy.NVPairs.(map[string]interface{})
+	for _, key := range settingsChanges(nvPairs, existing.S("nvPairs")) {
+		changes = append(changes, "nvPairs."+key)
+	}
+	return changes
+}
+
+// existingPolicyIDs returns the IDs of the policies of a switch which use the
+// template of policy and have its source and entity, where those are set.
+func existingPolicyIDs(policies []*container.Container, policy *models.Policy) []string {
+	ids := make([]string, 0)
+	for _, policyCont := range policies {
+		if models.G(policyCont, "deleted") == "true" || models.G(policyCont, "templateName") != policy.TemplateName {
+			continue
+		}
+		if policy.Source != "" && models.G(policyCont, "source") != policy.Source {
+			continue
+		}
+		if policy.EntityType != "" && models.G(policyCont, "entityType") != policy.EntityType {
+			continue
+		}
+		if policy.EntityName != "" && models.G(policyCont, "entityName") != policy.EntityName {
+			continue
+		}
+		ids = append(ids, models.G(policyCont, "id"))
+	}
+	return ids
+}
+
 func getAllPolicy(client *client.Client, policyId string) (*container.Container, error) {
 	duro := fmt.Sprintf(policyURLs["Common"], policyId)
 	cont, err := client.GetviaURL(duro)
@@ -291,7 +392,7 @@ func resourceDCNMPolicyRead(ctx context.Context, d *schema.ResourceData, m inter
 	childPolicyUrl := fmt.Sprintf(policyURLs["GetPolicy"], d.Get("serial_number"), d.Id())
 	cont, err = dcnmClient.GetviaURL(childPolicyUrl)
 	if err != nil {
-		return diag.Errorf("error child policy deletion: %w", err)
+		return diag.Errorf("error child policy deletion: %s", err)
 	}
 	childPolicies := []interface{}{}
 	json.Unmarshal(cont.Bytes(), &childPolicies)
@@ -375,7 +476,7 @@ func resourceDCNMPolicyDelete(ctx context.Context, d *schema.ResourceData, m int
 	url := fmt.Sprintf(policyURLs["GetFabricName"], serialNumber)
 	cont, err := dcnmClient.GetviaURL(url)
 	if err != nil {
-		return diag.Errorf("error deploying fabric after policy deletion: %w", err)
+		return diag.Errorf("error deploying fabric after policy deletion: %s", err)
 	}
 	fabric := models.G(cont, "fabricName")
 
@@ -441,7 +542,7 @@ func resourceDCNMPolicyDelete(ctx context.Context, d *schema.ResourceData, m int
 
 		isDeployed, err := checkDeploy(dcnmClient, fabric, serialNumber)
 		if err != nil {
-			return diag.Errorf("error deploying fabric after policy deletion: %w", err)
+			return diag.Errorf("error deploying fabric after policy deletion: %s", err)
 		}
 		if isDeployed {
 			break
@@ -3,9 +3,11 @@ package dcnm
 import (
 	"fmt"
 	"log"
+	"reflect"
 	"testing"
 
 	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/container"
 	"github.com/ciscoecosystem/dcnm-go-client/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -54,6 +56,58 @@ func TestAccDCNMPolicy_Update(t *testing.T) {
 	})
 
 }
+func TestExistingPolicyIDs(t *testing.T) {
+	cont, _ := container.ParseJSON([]byte(`[
+		{"id":1,"templateName":"switch_freeform","source":"","entityType":"SWITCH","entityName":"SWITCH","deleted":false},
+		{"id":2,"templateName":"switch_freeform","source":"UNDERLAY","entityType":"SWITCH","entityName":"SWITCH","deleted":false},
+		{"id":3,"templateName":"switch_freeform","source":"","entityType":"INTERFACE","entityName":"Ethernet1/1","deleted":false},
+		{"id":4,"templateName":"switch_freeform","source":"","entityType":"SWITCH","entityName":"SWITCH","deleted":true},
+		{"id":5,"templateName":"feature_ntp","source":"","entityType":"SWITCH","entityName":"SWITCH","deleted":false}
+	]`))
+
+	cases := []struct {
+		policy   models.Policy
+		expected []string
+	}{
+		{models.Policy{TemplateName: "switch_freeform"}, []string{"1", "2", "3"}},
+		{models.Policy{TemplateName: "switch_freeform", EntityType: "SWITCH"}, []string{"1", "2"}},
+		{models.Policy{TemplateName: "switch_freeform", Source: "UNDERLAY"}, []string{"2"}},
+		{models.Policy{TemplateName: "switch_freeform", EntityName: "Ethernet1/1"}, []string{"3"}},
+		{models.Policy{TemplateName: "feature_bfd"}, []string{}},
+	}
+
+	for _, c := range cases {
+		ids := existingPolicyIDs(cont.Children(), &c.policy)
+		if !reflect.DeepEqual(ids, c.expected) {
+			t.Errorf("%+v: expected %v, got %v", c.policy, c.expected, ids)
+		}
+	}
+}
+
+func TestPolicyAdoptChanges(t *testing.T) {
+	existing, _ := container.ParseJSON([]byte(`{
+		"id":1,"templateName":"switch_freeform","description":"ntp","priority":500,
+		"nvPairs":{"CONF":"feature ntp","PRIORITY":"500","FABRIC_NAME":"fab2"}
+	}`))
+
+	cases := []struct {
+		policy   models.Policy
+		expected []string
+	}{
+		{models.Policy{NVPairs: map[string]interface{}{"CONF": "feature ntp"}}, []string{}},
+		{models.Policy{Description: "ntp", Priority: "500", NVPairs: map[string]interface{}{"CONF": "feature ntp", "PRIORITY": "500"}}, []string{}},
+		{models.Policy{Priority: "100", NVPairs: map[string]interface{}{"CONF": "feature ntp"}}, []string{"priority"}},
+		{models.Policy{Description: "bfd", NVPairs: map[string]interface{}{"CONF": "feature bfd", "SECTION": "GLOBAL"}}, []string{"description", "nvPairs.CONF", "nvPairs.SECTION"}},
+	}
+
+	for _, c := range cases {
+		changes := policyAdoptChanges(&c.policy, existing)
+		if !reflect.DeepEqual(changes, c.expected) {
+			t.Errorf("%+v: expected %v, got %v", c.policy, c.expected, changes)
+		}
+	}
+}
+
 func testAccCheckDCNMPolicyExists(name string, policy *models.Policy) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
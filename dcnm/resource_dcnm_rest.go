@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/ciscoecosystem/dcnm-go-client/client"
 	"github.com/ciscoecosystem/dcnm-go-client/container"
@@ -20,8 +23,9 @@ func resourceDCNMRest() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"path": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				AtLeastOneOf: []string{"path", "create"},
 			},
 
 			"method": &schema.Schema{
@@ -48,38 +52,300 @@ func resourceDCNMRest() *schema.Resource {
 				}, false),
 				Default: "json",
 			},
+
+			"create": restOperationSchema(),
+
+			"update": restOperationSchema(),
+
+			"delete": restOperationSchema(),
+
+			"id_path": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"wait": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"method": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "GET",
+							ValidateFunc: validation.StringInSlice([]string{
+								"GET",
+								"POST",
+							}, false),
+						},
+
+						"interval": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      10,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+
+						"timeout": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      600,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+
+						"success_conditions": &schema.Schema{
+							Type:     schema.TypeMap,
+							Required: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+
+						"failure_conditions": &schema.Schema{
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+
+						"message_path": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"read_path": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"read_method": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "GET",
+				ValidateFunc: validation.StringInSlice([]string{
+					"GET",
+					"POST",
+				}, false),
+			},
+
+			"compare_mode": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "full",
+				ValidateFunc: validation.StringInSlice([]string{
+					"full",
+					"paths",
+				}, false),
+			},
+
+			"compare_paths": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"response": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// restOperationSchema is the schema of the create, update and delete blocks,
+// unset attributes default to the top level attributes.
+func restOperationSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"method": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						"GET",
+						"PUT",
+						"POST",
+						"DELETE",
+					}, false),
+				},
+
+				"path": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+
+				"payload": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+
+				"headers": &schema.Schema{
+					Type:     schema.TypeMap,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+
+				"query_params": &schema.Schema{
+					Type:     schema.TypeMap,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
 		},
 	}
 }
 
+// restOperation is a single request of the lifecycle of a dcnm_rest resource.
+// Requests of a create, update or delete block are Explicit and accept any 2xx
+// status, the top level request only accepts 200 as before.
+type restOperation struct {
+	Method   string
+	Path     string
+	Payload  string
+	Headers  map[string]string
+	Query    map[string]string
+	Explicit bool
+}
+
+// getRestOperation returns the request of the create, update or delete
+// block, falling back to the top level attributes. ${id} is replaced by id
+// in the path, payload, headers and query parameters.
+func getRestOperation(d *schema.ResourceData, name, defaultMethod, id string) restOperation {
+	op := restOperation{
+		Method:  defaultMethod,
+		Path:    d.Get("path").(string),
+		Payload: d.Get("payload").(string),
+		Headers: make(map[string]string),
+		Query:   make(map[string]string),
+	}
+	if method, ok := d.GetOk("method"); ok {
+		op.Method = method.(string)
+	}
+
+	if blocks := d.Get(name).([]interface{}); len(blocks) > 0 && blocks[0] != nil {
+		block := blocks[0].(map[string]interface{})
+		op.Explicit = true
+		if method := block["method"].(string); method != "" {
+			op.Method = method
+		}
+		if path := block["path"].(string); path != "" {
+			op.Path = path
+		}
+		if payload := block["payload"].(string); payload != "" {
+			op.Payload = payload
+		}
+		for key, value := range block["headers"].(map[string]interface{}) {
+			op.Headers[key] = value.(string)
+		}
+		for key, value := range block["query_params"].(map[string]interface{}) {
+			op.Query[key] = value.(string)
+		}
+	}
+
+	if id != "" {
+		op.Path = restInterpolate(op.Path, id)
+		op.Payload = restInterpolate(op.Payload, id)
+		for key, value := range op.Headers {
+			op.Headers[key] = restInterpolate(value, id)
+		}
+		for key, value := range op.Query {
+			op.Query[key] = restInterpolate(value, id)
+		}
+	}
+
+	return op
+}
+
+func restInterpolate(value, id string) string {
+	return strings.ReplaceAll(value, "${id}", id)
+}
+
+// url returns the path of the operation with its query parameters.
+func (op restOperation) url() string {
+	if len(op.Query) == 0 {
+		return op.Path
+	}
+
+	query := url.Values{}
+	for key, value := range op.Query {
+		query.Set(key, value)
+	}
+	if strings.Contains(op.Path, "?") {
+		return op.Path + "&" + query.Encode()
+	}
+	return op.Path + "?" + query.Encode()
+}
+
+func doRestOperation(client *client.Client, op restOperation, payloadType string) (*container.Container, error) {
+	var req *http.Request
+	var err error
+
+	if payloadType == "text" {
+		req, err = client.MakeRequestForText(op.Method, op.url(), op.Payload, true)
+	} else {
+		req, err = newRestRequest(client, op.url(), op.Method, op.Payload)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range op.Headers {
+		req.Header.Set(key, value)
+	}
+
+	cont, resp, err := client.Do(req, false)
+	if resp == nil {
+		return nil, err
+	}
+	if op.Explicit && resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return cont, nil
+	}
+	return cont, checkerrorsRest(cont, resp)
+}
+
 func resourceDCNMRestCreate(d *schema.ResourceData, m interface{}) error {
 	log.Println("[DEBUG] Begining Create method ")
 
 	dcnmClient := m.(*client.Client)
-	path := d.Get("path").(string)
-	payload := d.Get("payload").(string)
-
-	var op string
 
-	if method, ok := d.GetOk("method"); ok {
-		op = method.(string)
-	} else {
-		op = "POST"
+	op := getRestOperation(d, "create", "POST", "")
+	cont, err := doRestOperation(dcnmClient, op, d.Get("payload_type").(string))
+	if err != nil {
+		return err
 	}
 
-	if d.Get("payload_type").(string) == "json" {
-		_, err := makeAndDoRest(dcnmClient, path, op, payload)
-		if err != nil {
-			return err
+	if idPath, ok := d.GetOk("id_path"); ok {
+		id, found := jsonPathValue(cont, idPath.(string))
+		if !found || id == "" {
+			return fmt.Errorf("id_path %s not found in the response of %s", idPath, op.Path)
 		}
+		d.SetId(id)
 	} else {
-		_, err := makeAndDoRestForText(dcnmClient, path, op, payload)
-		if err != nil {
-			return err
-		}
+		d.SetId(op.Path)
 	}
 
-	d.SetId(path)
+	if err := waitForRest(dcnmClient, d); err != nil {
+		return err
+	}
 
 	log.Println("[DEBUG] End of Create method ", d.Id())
 	return resourceDCNMRestRead(d, m)
@@ -89,112 +355,325 @@ func resourceDCNMRestUpdate(d *schema.ResourceData, m interface{}) error {
 	log.Println("[DEBUG] Begining Update method ", d.Id())
 
 	dcnmClient := m.(*client.Client)
-	path := d.Get("path").(string)
-	payload := d.Get("payload").(string)
 
-	var op string
+	op := getRestOperation(d, "update", "PUT", d.Id())
+	if op.Path == "" {
+		return fmt.Errorf("path or update block is required to update %s", d.Id())
+	}
+	_, err := doRestOperation(dcnmClient, op, d.Get("payload_type").(string))
+	if err != nil {
+		return err
+	}
 
-	if method, ok := d.GetOk("method"); ok {
-		op = method.(string)
-	} else {
-		op = "PUT"
+	if _, ok := d.GetOk("id_path"); !ok && len(d.Get("create").([]interface{})) == 0 {
+		d.SetId(op.Path)
 	}
 
-	if d.Get("payload_type").(string) == "json" {
-		_, err := makeAndDoRest(dcnmClient, path, op, payload)
-		if err != nil {
-			return err
+	if err := waitForRest(dcnmClient, d); err != nil {
+		return err
+	}
+
+	log.Println("[DEBUG] End of Update method ", d.Id())
+	return resourceDCNMRestRead(d, m)
+}
+
+// matchRestConditions returns whether the values at the JSON paths of
+// conditions match. With all set, every condition has to match, otherwise a
+// single one.
+func matchRestConditions(cont *container.Container, conditions map[string]interface{}, all bool) bool {
+	if len(conditions) == 0 {
+		return false
+	}
+	for path, expected := range conditions {
+		value, ok := jsonPathValue(cont, path)
+		matched := ok && value == expected.(string)
+		if matched && !all {
+			return true
 		}
-	} else {
-		_, err := makeAndDoRestForText(dcnmClient, path, op, payload)
-		if err != nil {
-			return err
+		if !matched && all {
+			return false
 		}
 	}
+	return all
+}
 
-	d.SetId(path)
+// waitForRest polls the path of the wait block until its success or failure
+// conditions match.
+func waitForRest(dcnmClient *client.Client, d *schema.ResourceData) error {
+	blocks := d.Get("wait").([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return nil
+	}
+	wait := blocks[0].(map[string]interface{})
+
+	path := restInterpolate(wait["path"].(string), d.Id())
+	interval := time.Duration(wait["interval"].(int)) * time.Second
+	timeout := time.Duration(wait["timeout"].(int)) * time.Second
+	success := wait["success_conditions"].(map[string]interface{})
+	failure := wait["failure_conditions"].(map[string]interface{})
+	messagePath := wait["message_path"].(string)
+
+	message := func(cont *container.Container) string {
+		if messagePath != "" {
+			if value, ok := jsonPathValue(cont, messagePath); ok {
+				return value
+			}
+		}
+		return cont.String()
+	}
 
-	log.Println("[DEBUG] End of Update method ", d.Id())
-	return resourceDCNMRestRead(d, m)
+	initTime := time.Now()
+	for time.Since(initTime) < timeout {
+		time.Sleep(interval)
+
+		cont, resp, err := doRest(dcnmClient, path, wait["method"].(string), "")
+		if err == nil && resp != nil {
+			err = checkerrorsRest(cont, resp)
+		}
+		if err != nil {
+			log.Printf("[DEBUG] error at polling %s: %s", path, err)
+			continue
+		}
+		if cont == nil {
+			log.Printf("[DEBUG] no JSON response at polling %s", path)
+			continue
+		}
+
+		if matchRestConditions(cont, failure, false) {
+			return fmt.Errorf("operation of %s failed: %s", d.Id(), message(cont))
+		}
+		if matchRestConditions(cont, success, true) {
+			return nil
+		}
+		log.Printf("[DEBUG] operation of %s in progress: %s", d.Id(), message(cont))
+	}
+	return fmt.Errorf("timeout occurs before the operation of %s finished at %s", d.Id(), path)
 }
 
 func resourceDCNMRestRead(d *schema.ResourceData, m interface{}) error {
-	return nil
-}
+	log.Println("[DEBUG] Begining Read method ", d.Id())
 
-func resourceDCNMRestDelete(d *schema.ResourceData, m interface{}) error {
-	log.Println("[DEBUG] Begining Delete method ", d.Id())
+	readPath := restInterpolate(d.Get("read_path").(string), d.Id())
+	if readPath == "" {
+		log.Println("[DEBUG] End of Read method, no read_path ", d.Id())
+		return nil
+	}
 
 	dcnmClient := m.(*client.Client)
-	path := d.Get("path").(string)
-	payload := d.Get("payload").(string)
 
-	var op string
+	cont, resp, err := doRest(dcnmClient, readPath, d.Get("read_method").(string), "")
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		log.Printf("[WARN] %s not found, removing %s from state", readPath, d.Id())
+		d.SetId("")
+		return nil
+	}
+	if resp == nil {
+		return err
+	}
+	if err = checkerrorsRest(cont, resp); err != nil {
+		return err
+	}
 
-	if method, ok := d.GetOk("method"); ok {
-		op = method.(string)
-	} else {
-		op = "DELETE"
+	if cont == nil {
+		d.Set("response", "")
+		log.Println("[DEBUG] End of Read method ", d.Id())
+		return nil
 	}
+	d.Set("response", cont.String())
 
-	if d.Get("payload_type").(string) == "json" {
-		_, err := makeAndDoRest(dcnmClient, path, op, payload)
-		if err != nil {
-			return err
-		}
+	payload, payloadAttr := restSentPayload(d)
+	if d.Get("payload_type").(string) != "json" || strings.TrimSpace(payload) == "" {
+		log.Println("[DEBUG] End of Read method ", d.Id())
+		return nil
+	}
+
+	payloadCont, err := container.ParseJSON([]byte(payload))
+	if err != nil {
+		return err
+	}
+
+	drift := false
+	if d.Get("compare_mode").(string) == "full" {
+		var value interface{}
+		value, drift = restResponseValue(payloadCont.Data(), cont.Data())
+		payloadCont = container.Wrap(value)
 	} else {
-		_, err := makeAndDoRestForText(dcnmClient, path, op, payload)
-		if err != nil {
-			return err
+		for _, path := range interfaceToStrList(d.Get("compare_paths")) {
+			configured := payloadCont.Path(path)
+			if configured == nil {
+				log.Printf("[DEBUG] compare path %s not set in the payload", path)
+				continue
+			}
+
+			var value interface{}
+			changed := true
+			if remote := cont.Path(path); remote != nil {
+				value, changed = restResponseValue(configured.Data(), remote.Data())
+			}
+			if changed {
+				if _, err := payloadCont.SetP(value, path); err != nil {
+					return err
+				}
+				drift = true
+			}
 		}
 	}
 
-	d.SetId("")
+	// the payload is only replaced on a difference, so formatting of the
+	// configured payload does not show up as a diff
+	if drift {
+		log.Printf("[DEBUG] payload of %s differs from %s", d.Id(), readPath)
+		setRestPayload(d, payloadAttr, payloadCont.String())
+	}
 
-	log.Println("[DEBUG] End of Delete method ")
+	log.Println("[DEBUG] End of Read method ", d.Id())
 	return nil
 }
 
-func makeAndDoRest(client *client.Client, path, op, payload string) (*container.Container, error) {
+func resourceDCNMRestDelete(d *schema.ResourceData, m interface{}) error {
+	log.Println("[DEBUG] Begining Delete method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
 
-	jsonPayload, err := container.ParseJSON([]byte(payload))
+	op := getRestOperation(d, "delete", "DELETE", d.Id())
+	if op.Path == "" {
+		log.Printf("[WARN] no delete path for %s, removing it from state only", d.Id())
+		d.SetId("")
+		return nil
+	}
+	_, err := doRestOperation(dcnmClient, op, d.Get("payload_type").(string))
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	var req *http.Request
+	d.SetId("")
 
-	if client.GetPlatform() == "nd" {
-		req, err = client.MakeRestNDRequest(op, path, jsonPayload, true)
-		if err != nil {
-			return nil, err
+	log.Println("[DEBUG] End of Delete method ")
+	return nil
+}
+
+// restSentPayload returns the payload sent on creation and the attribute
+// holding it, the payload of the create block or the top level payload.
+func restSentPayload(d *schema.ResourceData) (string, string) {
+	if blocks := d.Get("create").([]interface{}); len(blocks) > 0 && blocks[0] != nil {
+		if payload := blocks[0].(map[string]interface{})["payload"].(string); payload != "" {
+			return payload, "create"
 		}
-	} else {
-		req, err = client.MakeRequest(op, path, jsonPayload, true)
+	}
+	return d.Get("payload").(string), "payload"
+}
+
+// setRestPayload replaces the payload held by attr, see restSentPayload.
+func setRestPayload(d *schema.ResourceData, attr, payload string) {
+	if attr != "create" {
+		d.Set(attr, payload)
+		return
+	}
+	block := d.Get("create").([]interface{})[0].(map[string]interface{})
+	block["payload"] = payload
+	d.Set("create", []interface{}{block})
+}
+
+// newRestRequest returns a JSON request without the lan-fabric prefix on ND.
+// An empty payload sends a request without body.
+func newRestRequest(client *client.Client, path, op, payload string) (*http.Request, error) {
+	var jsonPayload *container.Container
+	if strings.TrimSpace(payload) != "" {
+		var err error
+		jsonPayload, err = container.ParseJSON([]byte(payload))
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	respCont, resp, err := client.Do(req, false)
+	if client.GetPlatform() == "nd" {
+		return client.MakeRestNDRequest(op, path, jsonPayload, true)
+	}
+	return client.MakeRequest(op, path, jsonPayload, true)
+}
+
+func doRest(client *client.Client, path, op, payload string) (*container.Container, *http.Response, error) {
+	req, err := newRestRequest(client, path, op, payload)
 	if err != nil {
-		return nil, checkerrorsRest(respCont, resp)
+		return nil, nil, err
 	}
 
-	return respCont, checkerrorsRest(respCont, resp)
+	return client.Do(req, false)
 }
 
-func makeAndDoRestForText(client *client.Client, path, op, content string) (*container.Container, error) {
-	req, err := client.MakeRequestForText(op, path, content, true)
-	if err != nil {
-		return nil, err
+// jsonPathValue returns the value at a dot separated path of a JSON document,
+// e.g. "data.0.name". Strings are returned as is, any other value as JSON.
+// An empty path returns the whole document.
+func jsonPathValue(cont *container.Container, path string) (string, bool) {
+	if cont == nil {
+		return "", false
 	}
-	req.Header.Set("Content-Type", "text/plain")
-	cont, resp, err := client.Do(req, false)
-	if err != nil {
-		return nil, err
+	if path != "" {
+		cont = cont.Path(path)
+		if cont == nil {
+			return "", false
+		}
 	}
-	return cont, checkerrorsRest(cont, resp)
+	if value, ok := cont.Data().(string); ok {
+		return value, true
+	}
+	return cont.String(), true
+}
+
+// restResponseValue returns the value of the response shaped like the payload
+// and whether it differs from the payload. Only keys present in both are
+// compared: keys only known to the controller are ignored and payload keys the
+// controller does not return, e.g. passwords, keep their payload value, as do
+// values returned in a different shape. Values are compared by their string
+// form so "10" and 10 match.
+func restResponseValue(payload, response interface{}) (interface{}, bool) {
+	switch payloadValue := payload.(type) {
+	case map[string]interface{}:
+		responseMap, ok := response.(map[string]interface{})
+		if !ok {
+			return payload, false
+		}
+		value, drift := make(map[string]interface{}), false
+		for key, item := range payloadValue {
+			remote, ok := responseMap[key]
+			if !ok {
+				value[key] = item
+				continue
+			}
+			itemValue, itemDrift := restResponseValue(item, remote)
+			value[key] = itemValue
+			drift = drift || itemDrift
+		}
+		return value, drift
+	case []interface{}:
+		responseList, ok := response.([]interface{})
+		if !ok {
+			return payload, false
+		}
+		if len(responseList) != len(payloadValue) {
+			return response, true
+		}
+		value, drift := make([]interface{}, len(payloadValue)), false
+		for i, item := range payloadValue {
+			itemValue, itemDrift := restResponseValue(item, responseList[i])
+			value[i] = itemValue
+			drift = drift || itemDrift
+		}
+		return value, drift
+	}
+
+	if _, ok := response.(map[string]interface{}); ok {
+		return payload, false
+	}
+	if _, ok := response.([]interface{}); ok {
+		return payload, false
+	}
+	configured, _ := jsonPathValue(container.Wrap(payload), "")
+	remote, _ := jsonPathValue(container.Wrap(response), "")
+	if configured != remote {
+		return response, true
+	}
+	return payload, false
 }
 
 func checkerrorsRest(cont *container.Container, resp *http.Response) error {
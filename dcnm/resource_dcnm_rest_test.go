@@ -0,0 +1,136 @@
+package dcnm
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ciscoecosystem/dcnm-go-client/container"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestRestResponseValue(t *testing.T) {
+	payload, _ := container.ParseJSON([]byte(`{"networkName":"import","networkId":"30006","tags":["a","b"],"config":{"vlanId":2300}}`))
+
+	cases := []struct {
+		name     string
+		response string
+		drift    bool
+		expected string
+	}{
+		{
+			name:     "in sync with extra keys",
+			response: `{"networkName":"import","networkId":30006,"tags":["a","b"],"config":{"vlanId":"2300","mtu":1500},"id":7}`,
+			drift:    false,
+			expected: `{"config":{"vlanId":2300},"networkId":"30006","networkName":"import","tags":["a","b"]}`,
+		},
+		{
+			name:     "changed nested value",
+			response: `{"networkName":"import","networkId":"30006","tags":["a","b"],"config":{"vlanId":2400}}`,
+			drift:    true,
+			expected: `{"config":{"vlanId":2400},"networkId":"30006","networkName":"import","tags":["a","b"]}`,
+		},
+		{
+			name:     "changed list",
+			response: `{"networkName":"import","networkId":"30006","tags":["a"],"config":{"vlanId":2300}}`,
+			drift:    true,
+			expected: `{"config":{"vlanId":2300},"networkId":"30006","networkName":"import","tags":["a"]}`,
+		},
+		{
+			name:     "key not returned",
+			response: `{"networkName":"import","tags":["a","b"],"config":{"vlanId":2300}}`,
+			drift:    false,
+			expected: `{"config":{"vlanId":2300},"networkId":"30006","networkName":"import","tags":["a","b"]}`,
+		},
+		{
+			name:     "value in a different shape",
+			response: `{"networkName":"import","networkId":"30006","tags":{"a":"b"},"config":[{"vlanId":2300}]}`,
+			drift:    false,
+			expected: `{"config":{"vlanId":2300},"networkId":"30006","networkName":"import","tags":["a","b"]}`,
+		},
+	}
+
+	for _, c := range cases {
+		response, err := container.ParseJSON([]byte(c.response))
+		if err != nil {
+			t.Fatal(err)
+		}
+		value, drift := restResponseValue(payload.Data(), response.Data())
+		if drift != c.drift {
+			t.Errorf("%s: expected drift %t, got %t", c.name, c.drift, drift)
+		}
+		expected, _ := container.ParseJSON([]byte(c.expected))
+		if !reflect.DeepEqual(value, expected.Data()) {
+			t.Errorf("%s: expected %s, got %s", c.name, expected.String(), container.Wrap(value).String())
+		}
+	}
+}
+
+func TestRestResponseValueWriteOnly(t *testing.T) {
+	payload, _ := container.ParseJSON([]byte(`{"userName":"admin","password":"secret","roles":["network-admin"]}`))
+	response, _ := container.ParseJSON([]byte(`{"userName":"admin","roles":["network-admin"],"passwordExpiry":90}`))
+
+	value, drift := restResponseValue(payload.Data(), response.Data())
+	if drift {
+		t.Errorf("expected no drift for the write-only password, got %s", container.Wrap(value).String())
+	}
+	if !reflect.DeepEqual(value, payload.Data()) {
+		t.Errorf("expected the payload to be kept, got %s", container.Wrap(value).String())
+	}
+}
+
+func TestMatchRestConditions(t *testing.T) {
+	cont, _ := container.ParseJSON([]byte(`{"status":"COMPLETED","progress":100,"jobs":[{"state":"SUCCESS"}]}`))
+
+	cases := []struct {
+		conditions map[string]interface{}
+		all        bool
+		expected   bool
+	}{
+		{map[string]interface{}{"status": "COMPLETED", "jobs.0.state": "SUCCESS"}, true, true},
+		{map[string]interface{}{"status": "COMPLETED", "progress": "50"}, true, false},
+		{map[string]interface{}{"status": "FAILED", "progress": "100"}, false, true},
+		{map[string]interface{}{"status": "FAILED", "message": "error"}, false, false},
+		{map[string]interface{}{}, false, false},
+	}
+
+	for _, c := range cases {
+		if matched := matchRestConditions(cont, c.conditions, c.all); matched != c.expected {
+			t.Errorf("conditions %v (all %t): expected %t, got %t", c.conditions, c.all, c.expected, matched)
+		}
+	}
+}
+
+func TestRestSentPayload(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDCNMRest().Schema, map[string]interface{}{
+		"path":    "/rest/top-down/fabrics/fab2/networks",
+		"payload": `{"networkName":"top"}`,
+	})
+	if payload, attr := restSentPayload(d); payload != `{"networkName":"top"}` || attr != "payload" {
+		t.Errorf("expected the top level payload, got %s from %s", payload, attr)
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceDCNMRest().Schema, map[string]interface{}{
+		"payload": `{"networkName":"top"}`,
+		"create": []interface{}{
+			map[string]interface{}{
+				"path":    "/rest/top-down/fabrics/fab2/networks",
+				"payload": `{"networkName":"created"}`,
+			},
+		},
+	})
+	payload, attr := restSentPayload(d)
+	if payload != `{"networkName":"created"}` || attr != "create" {
+		t.Errorf("expected the create payload, got %s from %s", payload, attr)
+	}
+
+	setRestPayload(d, attr, `{"networkName":"remote"}`)
+	if payload := d.Get("create.0.payload").(string); payload != `{"networkName":"remote"}` {
+		t.Errorf("expected the create payload to be replaced, got %s", payload)
+	}
+	if path := d.Get("create.0.path").(string); path != "/rest/top-down/fabrics/fab2/networks" {
+		t.Errorf("expected the create path to be kept, got %s", path)
+	}
+	if payload := d.Get("payload").(string); payload != `{"networkName":"top"}` {
+		t.Errorf("expected the top level payload to be kept, got %s", payload)
+	}
+}
@@ -0,0 +1,129 @@
+package dcnm
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/container"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceDCNMSecurityDomain() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDCNMSecurityDomainCreate,
+		UpdateContext: resourceDCNMSecurityDomainUpdate,
+		ReadContext:   resourceDCNMSecurityDomainRead,
+		DeleteContext: resourceDCNMSecurityDomainDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func getRemoteSecurityDomain(dcnmClient *client.Client, name string) (*container.Container, error) {
+	durl := fmt.Sprintf(UserURLS["nd"]["Domain"], name)
+	cont, err := makeAndDoRequest(dcnmClient, "GET", durl, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	if cont.Exists("spec") {
+		cont = cont.S("spec")
+	}
+	if models.G(cont, "name") != name {
+		return nil, fmt.Errorf("security domain %s not found", name)
+	}
+	return cont, nil
+}
+
+func resourceDCNMSecurityDomainCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Create method ")
+
+	dcnmClient := m.(*client.Client)
+	if dcnmClient.GetPlatform() != "nd" {
+		return diag.Errorf("security domains are only supported on ND")
+	}
+
+	name := d.Get("name").(string)
+	payload := map[string]interface{}{
+		"name":        name,
+		"description": d.Get("description").(string),
+	}
+	_, err := makeAndDoRequest(dcnmClient, "POST", UserURLS["nd"]["DomainCreate"], payload, true)
+	if err != nil {
+		return diag.Errorf("error at creation of security domain %s: %s", name, err)
+	}
+
+	d.SetId(name)
+	log.Println("[DEBUG] End of Create method ", d.Id())
+	return resourceDCNMSecurityDomainRead(ctx, d, m)
+}
+
+func resourceDCNMSecurityDomainUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Update method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+
+	payload := map[string]interface{}{
+		"name":        d.Id(),
+		"description": d.Get("description").(string),
+	}
+	_, err := makeAndDoRequest(dcnmClient, "PUT", fmt.Sprintf(UserURLS["nd"]["Domain"], d.Id()), payload, true)
+	if err != nil {
+		return diag.Errorf("error at update of security domain %s: %s", d.Id(), err)
+	}
+
+	log.Println("[DEBUG] End of Update method ", d.Id())
+	return resourceDCNMSecurityDomainRead(ctx, d, m)
+}
+
+func resourceDCNMSecurityDomainRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Read method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+
+	cont, err := getRemoteSecurityDomain(dcnmClient, d.Id())
+	if err != nil {
+		log.Printf("[DEBUG] security domain %s not found: %s", d.Id(), err)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", models.G(cont, "name"))
+	d.Set("description", models.G(cont, "description"))
+
+	log.Println("[DEBUG] End of Read method ", d.Id())
+	return nil
+}
+
+func resourceDCNMSecurityDomainDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Delete method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+
+	_, err := makeAndDoRequest(dcnmClient, "DELETE", fmt.Sprintf(UserURLS["nd"]["Domain"], d.Id()), nil, true)
+	if err != nil {
+		return diag.Errorf("error at deletion of security domain %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	log.Println("[DEBUG] End of Delete method ", d.Id())
+	return nil
+}
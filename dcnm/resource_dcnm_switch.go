@@ -0,0 +1,309 @@
+package dcnm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/container"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceDCNMSwitch() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDCNMSwitchCreate,
+		UpdateContext: resourceDCNMSwitchUpdate,
+		ReadContext:   resourceDCNMSwitchRead,
+		DeleteContext: resourceDCNMSwitchDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: resourceDCNMSwitchImporter,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"fabric_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"serial_number": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"role": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"leaf",
+					"spine",
+					"border",
+					"border_spine",
+					"border_gateway",
+					"border_gateway_spine",
+					"super_spine",
+					"border_super_spine",
+					"border_gateway_super_spine",
+				}, false),
+			},
+
+			"mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"normal",
+					"maintenance",
+				}, false),
+			},
+
+			"freeze": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+
+			"deploy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"config_timeout": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  5,
+			},
+
+			"switch_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"model": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func setSwitchFreezeMode(dcnmClient *client.Client, fabricName, serialNum string, freeze bool) error {
+	durl := fmt.Sprintf("/rest/control/fabrics/%s/switches/%s/freezemode?enable=%t", fabricName, serialNum, freeze)
+	_, err := dcnmClient.SaveAndDeploy(durl)
+	return err
+}
+
+// deploySwitchAndWait recalculates the fabric, deploys the pending config of
+// the switch and waits until the switch reports config compliance.
+func deploySwitchAndWait(dcnmClient *client.Client, fabricName, serialNum string, configTime int) error {
+	durl := fmt.Sprintf("rest/control/fabrics/%s/config-save", fabricName)
+	_, err := dcnmClient.SaveAndDeploy(durl)
+	if err != nil {
+		return fmt.Errorf("error at config save of fabric %s: %s", fabricName, err)
+	}
+
+	err = deployswitch(dcnmClient, fabricName, serialNum)
+	if err != nil {
+		return fmt.Errorf("error at deployment of switch %s: %s", serialNum, err)
+	}
+
+	initTime := time.Now()
+	for time.Since(initTime) < (time.Duration(configTime) * time.Second) {
+		isDeployed, err := checkDeploy(dcnmClient, fabricName, serialNum)
+		if err != nil {
+			return err
+		}
+		if isDeployed {
+			return nil
+		}
+		time.Sleep(10 * time.Second)
+	}
+
+	return fmt.Errorf("timeout occurs before switch %s is in sync", serialNum)
+}
+
+func setSwitchModeAttributes(d *schema.ResourceData, dcnmClient *client.Client, cont *container.Container) *schema.ResourceData {
+	serialNum := models.G(cont, "serialNumber")
+
+	d.Set("serial_number", serialNum)
+	d.Set("switch_name", models.G(cont, "logicalName"))
+	d.Set("ip", models.G(cont, "ipAddress"))
+	d.Set("model", models.G(cont, "model"))
+
+	if models.G(cont, "mode") == "Maintenance" {
+		d.Set("mode", "maintenance")
+	} else {
+		d.Set("mode", "normal")
+	}
+	d.Set("freeze", models.G(cont, "freezeMode") == "true")
+
+	role, err := getSwitchRole(dcnmClient, serialNum)
+	if err == nil {
+		d.Set("role", strings.ReplaceAll(strings.Trim(role, " "), " ", "_"))
+	} else {
+		log.Println("error in read at fetching switch role :", serialNum, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", d.Get("fabric_name").(string), serialNum))
+	return d
+}
+
+func resourceDCNMSwitchImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	log.Println("[DEBUG] Begining Importer ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+	importInfo := strings.Split(d.Id(), ":")
+	if len(importInfo) != 2 {
+		return nil, fmt.Errorf("not getting enough arguments for the import operation")
+	}
+
+	cont, err := getRemoteSwitch(dcnmClient, importInfo[0], "", importInfo[1])
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("fabric_name", importInfo[0])
+	stateImport := setSwitchModeAttributes(d, dcnmClient, cont)
+	d.Set("deploy", true)
+	d.Set("config_timeout", 5)
+
+	log.Println("[DEBUG] End of Importer ", d.Id())
+	return []*schema.ResourceData{stateImport}, nil
+}
+
+func updateSwitchSettings(d *schema.ResourceData, dcnmClient *client.Client, cont *container.Container) error {
+	fabricName := d.Get("fabric_name").(string)
+	serialNum := d.Get("serial_number").(string)
+	changed := false
+
+	if role, ok := d.GetOk("role"); ok {
+		currentRole, err := getSwitchRole(dcnmClient, serialNum)
+		if err != nil || strings.ReplaceAll(strings.Trim(currentRole, " "), " ", "_") != role.(string) {
+			_, err = dcnmClient.SaveForAttachment(
+				"/rest/control/switches/roles",
+				&models.SwitchRole{
+					Role:         roleMappingFunc(role.(string)),
+					SerialNumber: serialNum,
+				},
+			)
+			if err != nil {
+				return fmt.Errorf("error at switch role assignment %s: %s", serialNum, err)
+			}
+			changed = true
+		}
+	}
+
+	if mode, ok := d.GetOk("mode"); ok {
+		maintenance := mode.(string) == "maintenance"
+		if maintenance != (models.G(cont, "mode") == "Maintenance") {
+			err := setSwitchMaintenanceMode(dcnmClient, fabricName, serialNum, maintenance)
+			if err != nil {
+				return fmt.Errorf("error at moving switch %s to %s mode: %s", serialNum, mode.(string), err)
+			}
+			changed = true
+		}
+	}
+
+	if freeze, ok := d.GetOkExists("freeze"); ok {
+		if freeze.(bool) != (models.G(cont, "freezeMode") == "true") {
+			err := setSwitchFreezeMode(dcnmClient, fabricName, serialNum, freeze.(bool))
+			if err != nil {
+				return fmt.Errorf("error at switch freeze mode update %s: %s", serialNum, err)
+			}
+			changed = true
+		}
+	}
+
+	if changed && d.Get("deploy").(bool) {
+		return deploySwitchAndWait(dcnmClient, fabricName, serialNum, d.Get("config_timeout").(int)*60)
+	}
+	return nil
+}
+
+func resourceDCNMSwitchCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Create method ")
+
+	dcnmClient := m.(*client.Client)
+	fabricName := d.Get("fabric_name").(string)
+	serialNum := d.Get("serial_number").(string)
+
+	cont, err := getRemoteSwitch(dcnmClient, fabricName, "", serialNum)
+	if err != nil {
+		return diag.Errorf("switch %s is not found in fabric %s", serialNum, fabricName)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", fabricName, serialNum))
+
+	err = updateSwitchSettings(d, dcnmClient, cont)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("[DEBUG] End of Create method ", d.Id())
+	return resourceDCNMSwitchRead(ctx, d, m)
+}
+
+func resourceDCNMSwitchUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Update method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+
+	if d.HasChanges("role", "mode", "freeze") {
+		cont, err := getRemoteSwitch(dcnmClient, d.Get("fabric_name").(string), "", d.Get("serial_number").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		err = updateSwitchSettings(d, dcnmClient, cont)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Println("[DEBUG] End of Update method ", d.Id())
+	return resourceDCNMSwitchRead(ctx, d, m)
+}
+
+func resourceDCNMSwitchRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Read method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+
+	cont, err := getRemoteSwitch(dcnmClient, d.Get("fabric_name").(string), "", d.Get("serial_number").(string))
+	if err != nil {
+		log.Printf("[DEBUG] switch %s not found: %s", d.Id(), err)
+		d.SetId("")
+		return nil
+	}
+
+	setSwitchModeAttributes(d, dcnmClient, cont)
+
+	log.Println("[DEBUG] End of Read method ", d.Id())
+	return nil
+}
+
+func resourceDCNMSwitchDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Delete method ", d.Id())
+
+	// The switch stays in the fabric with its current settings, use
+	// dcnm_inventory to remove switches from the fabric.
+	d.SetId("")
+
+	log.Println("[DEBUG] End of Delete method ", d.Id())
+	return nil
+}
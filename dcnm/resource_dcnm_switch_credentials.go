@@ -0,0 +1,221 @@
+package dcnm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/container"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceDCNMSwitchCredentials() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDCNMSwitchCredentialsCreate,
+		UpdateContext: resourceDCNMSwitchCredentialsUpdate,
+		ReadContext:   resourceDCNMSwitchCredentialsRead,
+		DeleteContext: resourceDCNMSwitchCredentialsDelete,
+
+		Schema: map[string]*schema.Schema{
+			"fabric_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"serial_number": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"username": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+
+			"password": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+
+			"auth_protocol": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
+
+			"switches": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// getCredentialSwitches returns the inventory entries targeted by the resource,
+// either the single configured switch or every switch of the fabric.
+func getCredentialSwitches(dcnmClient *client.Client, fabricName, serialNum string) ([]*container.Container, error) {
+	if serialNum != "" {
+		cont, err := getRemoteSwitch(dcnmClient, fabricName, "", serialNum)
+		if err != nil {
+			return nil, err
+		}
+		return []*container.Container{cont}, nil
+	}
+
+	durl := fmt.Sprintf("/rest/control/fabrics/%s/inventory", fabricName)
+	cont, err := dcnmClient.GetviaURL(durl)
+	if err != nil {
+		return nil, err
+	}
+
+	switchConts := cont.Children()
+	if len(switchConts) == 0 {
+		return nil, fmt.Errorf("no switches found in fabric %s", fabricName)
+	}
+	return switchConts, nil
+}
+
+func verifySwitchCredentials(dcnmClient *client.Client, fabricName, ip string, inv models.Inventory) error {
+	fabricID, err := extractFabricID(dcnmClient, fabricName)
+	if err != nil {
+		return err
+	}
+
+	inv.SeedIP = ip
+	dUrl := fmt.Sprintf("/rest/control/fabrics/%s/inventory/test-reachability", strconv.Itoa(fabricID))
+	cont, err := dcnmClient.Save(dUrl, &inv)
+	if err != nil {
+		return fmt.Errorf("error at test reachability for switch %s: %s", ip, err)
+	}
+
+	switchM := extractSwitchinfo(cont)
+	if switchM.Reachable != "true" {
+		return fmt.Errorf("switch %s is not reachable: %s", ip, switchM.StatReason)
+	}
+	if switchM.Auth != "true" {
+		return fmt.Errorf("invalid user/password or bad authentication protocol for switch %s", ip)
+	}
+
+	return nil
+}
+
+func saveSwitchCredentials(dcnmClient *client.Client, switchDbIDs []string, username, password string, authProtocol int) error {
+	body := []byte(fmt.Sprintf("switchIds=%s&userName=%s&password=%s&v3protocol=%s", strings.Join(switchDbIDs, ","), url.QueryEscape(username), url.QueryEscape(password), strconv.Itoa(authProtocol)))
+
+	durl := "/fm/fmrest/lanConfig/saveSwitchCredentials"
+	if dcnmClient.GetPlatform() == "nd" {
+		durl = "/rest/lanConfig/saveSwitchCredentials"
+	}
+	_, err := dcnmClient.UpdateCred(durl, body)
+	return err
+}
+
+func applySwitchCredentials(d *schema.ResourceData, dcnmClient *client.Client) error {
+	fabricName := d.Get("fabric_name").(string)
+
+	inv := models.Inventory{}
+	inv.Username = d.Get("username").(string)
+	inv.Password = d.Get("password").(string)
+	inv.V3auth = d.Get("auth_protocol").(int)
+
+	switchConts, err := getCredentialSwitches(dcnmClient, fabricName, d.Get("serial_number").(string))
+	if err != nil {
+		return err
+	}
+
+	// Verify every switch before anything is persisted, so a wrong password
+	// never replaces working credentials.
+	switchDbIDs := make([]string, 0, len(switchConts))
+	for _, cont := range switchConts {
+		ip := models.G(cont, "ipAddress")
+		if err := verifySwitchCredentials(dcnmClient, fabricName, ip, inv); err != nil {
+			return err
+		}
+		switchDbIDs = append(switchDbIDs, models.G(cont, "switchDbID"))
+	}
+
+	if err := saveSwitchCredentials(dcnmClient, switchDbIDs, inv.Username, inv.Password, inv.V3auth); err != nil {
+		return fmt.Errorf("error at credential update of switches in fabric %s: %s", fabricName, err)
+	}
+	return nil
+}
+
+func resourceDCNMSwitchCredentialsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Create method ")
+
+	dcnmClient := m.(*client.Client)
+
+	if err := applySwitchCredentials(d, dcnmClient); err != nil {
+		return diag.FromErr(err)
+	}
+
+	id := d.Get("fabric_name").(string)
+	if serialNum, ok := d.GetOk("serial_number"); ok {
+		id = fmt.Sprintf("%s:%s", id, serialNum.(string))
+	}
+	d.SetId(id)
+
+	log.Println("[DEBUG] End of Create method ", d.Id())
+	return resourceDCNMSwitchCredentialsRead(ctx, d, m)
+}
+
+func resourceDCNMSwitchCredentialsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Update method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+
+	if d.HasChanges("username", "password", "auth_protocol") {
+		if err := applySwitchCredentials(d, dcnmClient); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Println("[DEBUG] End of Update method ", d.Id())
+	return resourceDCNMSwitchCredentialsRead(ctx, d, m)
+}
+
+func resourceDCNMSwitchCredentialsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Read method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+
+	switchConts, err := getCredentialSwitches(dcnmClient, d.Get("fabric_name").(string), d.Get("serial_number").(string))
+	if err != nil {
+		log.Printf("[DEBUG] switches for credentials %s not found: %s", d.Id(), err)
+		d.SetId("")
+		return nil
+	}
+
+	serials := make([]string, 0, len(switchConts))
+	for _, cont := range switchConts {
+		serials = append(serials, models.G(cont, "serialNumber"))
+	}
+	d.Set("switches", serials)
+
+	log.Println("[DEBUG] End of Read method ", d.Id())
+	return nil
+}
+
+func resourceDCNMSwitchCredentialsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Delete method ", d.Id())
+
+	// DCNM/NDFC has no API to clear LAN credentials, the last saved values
+	// stay on the controller once the resource is removed from the state.
+	d.SetId("")
+
+	log.Println("[DEBUG] End of Delete method ", d.Id())
+	return nil
+}
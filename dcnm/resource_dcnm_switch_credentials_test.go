@@ -0,0 +1,120 @@
+package dcnm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var providerfSwitchCred *schema.Provider
+
+func TestAccDCNMSwitchCredentials_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactoriesInternal(&providerfSwitchCred),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDCNMSwitchCredentialsConfig_basic("ins3965!"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMSwitchCredentialsExists("dcnm_switch_credentials.test"),
+					resource.TestCheckResourceAttr("dcnm_switch_credentials.test", "switches.#", "1"),
+				),
+			},
+			{
+				Config: testAccCheckDCNMSwitchCredentialsConfig_basic("ins3965!"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMSwitchCredentialsExists("dcnm_switch_credentials.test"),
+					resource.TestCheckResourceAttr("dcnm_switch_credentials.test", "switches.0", "9Y0K4YPFFOA"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDCNMSwitchCredentials_Rotate(t *testing.T) {
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactoriesInternal(&providerfSwitchCred),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDCNMSwitchCredentialsConfig_basic("ins3965!"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMSwitchCredentialsExists("dcnm_switch_credentials.test"),
+					testAccCheckDCNMSwitchCredentialsID("dcnm_switch_credentials.test", &id),
+				),
+			},
+			{
+				Config: testAccCheckDCNMSwitchCredentialsConfig_basic("Rotat3d!"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMSwitchCredentialsExists("dcnm_switch_credentials.test"),
+					testAccCheckDCNMSwitchCredentialsID("dcnm_switch_credentials.test", &id),
+					resource.TestCheckResourceAttr("dcnm_switch_credentials.test", "password", "Rotat3d!"),
+				),
+			},
+			{
+				Config: testAccCheckDCNMSwitchCredentialsConfig_basic("ins3965!"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMSwitchCredentialsID("dcnm_switch_credentials.test", &id),
+					resource.TestCheckResourceAttr("dcnm_switch_credentials.test", "password", "ins3965!"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDCNMSwitchCredentialsConfig_basic(password string) string {
+	return fmt.Sprintf(`
+	resource "dcnm_switch_credentials" "test" {
+		fabric_name   = "fab2"
+		serial_number = "9Y0K4YPFFOA"
+		username      = "admin"
+		password      = "%s"
+		auth_protocol = 0
+	}
+	`, password)
+}
+
+func testAccCheckDCNMSwitchCredentialsExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+
+		if !ok {
+			return fmt.Errorf("Switch credentials %s not found", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Switch credentials dn was set")
+		}
+
+		dcnmClient := (*providerfSwitchCred).Meta().(*client.Client)
+
+		_, err := getRemoteSwitch(dcnmClient, rs.Primary.Attributes["fabric_name"], "", rs.Primary.Attributes["serial_number"])
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+}
+
+// testAccCheckDCNMSwitchCredentialsID checks that a rotation keeps the
+// resource instead of replacing it.
+func testAccCheckDCNMSwitchCredentialsID(name string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Switch credentials %s not found", name)
+		}
+
+		if *id == "" {
+			*id = rs.Primary.ID
+		} else if rs.Primary.ID != *id {
+			return fmt.Errorf("Switch credentials replaced on rotation, id %s changed to %s", *id, rs.Primary.ID)
+		}
+		return nil
+	}
+}
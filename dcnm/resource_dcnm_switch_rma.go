@@ -0,0 +1,307 @@
+package dcnm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/container"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var switchModeURLs = map[string]string{
+	"MaintenanceMode": "/rest/control/fabrics/%s/switches/%s/maintenance-mode",
+	"RMA":             "/rest/control/fabrics/%s/rma",
+}
+
+func resourceDCNMSwitchRMA() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDCNMSwitchRMACreate,
+		ReadContext:   resourceDCNMSwitchRMARead,
+		DeleteContext: resourceDCNMSwitchRMADelete,
+
+		Schema: map[string]*schema.Schema{
+			"fabric_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"old_serial_number": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"new_serial_number": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"password": {
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				Sensitive: true,
+			},
+
+			"auth_protocol": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Default:  0,
+			},
+
+			"discovery_username": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"discovery_password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				ForceNew:  true,
+				Sensitive: true,
+			},
+
+			"gateway": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"model": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"modules_model": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"image_policy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"config_timeout": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Default:  20,
+			},
+
+			"deploy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  true,
+			},
+
+			"ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"switch_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func setSwitchMaintenanceMode(dcnmClient *client.Client, fabricName, serialNum string, maintenance bool) error {
+	durl := fmt.Sprintf(switchModeURLs["MaintenanceMode"], fabricName, serialNum)
+	if maintenance {
+		_, err := dcnmClient.SaveAndDeploy(durl)
+		return err
+	}
+	_, err := dcnmClient.Delete(durl)
+	return err
+}
+
+func waitUntilSwitchManaged(dcnmClient *client.Client, fabricName, serialNum string, configTimeout int) (*container.Container, error) {
+	initTime := time.Now()
+	for time.Since(initTime) < (time.Duration(configTimeout) * time.Second) {
+		time.Sleep(10 * time.Second)
+		cont, err := getRemoteSwitch(dcnmClient, fabricName, "", serialNum)
+		if err != nil {
+			log.Println("Error at get call for switch in replacement :", serialNum, err)
+			continue
+		}
+
+		if models.G(cont, "mode") != "Migration" && models.G(cont, "status") == "ok" {
+			return cont, nil
+		}
+	}
+	return nil, fmt.Errorf("timeout occurs before switch %s finished booting and became managed", serialNum)
+}
+
+func resourceDCNMSwitchRMACreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Create method ")
+
+	dcnmClient := m.(*client.Client)
+
+	fabricName := d.Get("fabric_name").(string)
+	oldSerial := d.Get("old_serial_number").(string)
+	newSerial := d.Get("new_serial_number").(string)
+
+	oldCont, err := getRemoteSwitch(dcnmClient, fabricName, "", oldSerial)
+	if err != nil {
+		return diag.Errorf("switch %s to be replaced is not found in fabric %s", oldSerial, fabricName)
+	}
+
+	poapCont, err := getPOAPSwitches(dcnmClient, fabricName)
+	if err != nil {
+		return diag.Errorf("error at fetching POAP list of fabric %s: %s", fabricName, err)
+	}
+	bootCont, err := poapCont.SearchInObjectList(func(tempCont *container.Container) bool {
+		return models.G(tempCont, "serialNumber") == newSerial
+	})
+	if err != nil {
+		return diag.Errorf("replacement switch %s is not in the POAP list of fabric %s", newSerial, fabricName)
+	}
+
+	model := d.Get("model").(string)
+	if model == "" {
+		model = models.G(bootCont, "model")
+	}
+	version := d.Get("version").(string)
+	if version == "" {
+		version = models.G(bootCont, "version")
+	}
+	modules := interfaceToStrList(d.Get("modules_model"))
+	if len(modules) == 0 {
+		if dataCont, err := cleanJsonString(models.G(bootCont, "data")); err == nil && dataCont.Exists("modulesModel") {
+			modules = interfaceToStrList(dataCont.S("modulesModel").Data())
+		}
+	}
+
+	// RMA is only allowed for switches in maintenance mode
+	if models.G(oldCont, "mode") != "Maintenance" {
+		err = setSwitchMaintenanceMode(dcnmClient, fabricName, oldSerial, true)
+		if err != nil {
+			return diag.Errorf("error at moving switch %s to maintenance mode: %s", oldSerial, err)
+		}
+	}
+
+	data, err := json.Marshal(map[string]interface{}{
+		"gateway":      d.Get("gateway").(string),
+		"modulesModel": modules,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	payload := map[string]interface{}{
+		"oldSerialNumber":       oldSerial,
+		"newSerialNumber":       newSerial,
+		"model":                 model,
+		"version":               version,
+		"gateway":               d.Get("gateway").(string),
+		"modulesModel":          modules,
+		"password":              d.Get("password").(string),
+		"discoveryAuthProtocol": d.Get("auth_protocol").(int),
+		"data":                  string(data),
+	}
+	if policy, ok := d.GetOk("image_policy"); ok {
+		payload["imagePolicy"] = policy.(string)
+	}
+	if username, ok := d.GetOk("discovery_username"); ok {
+		payload["discoveryUsername"] = username.(string)
+		payload["discoveryPassword"] = d.Get("discovery_password").(string)
+	}
+
+	_, err = makeAndDoRequest(dcnmClient, "POST", fmt.Sprintf(switchModeURLs["RMA"], fabricName), payload, false)
+	if err != nil {
+		return diag.Errorf("error at replacement of switch %s with %s: %s", oldSerial, newSerial, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", fabricName, newSerial))
+	d.Set("model", model)
+	d.Set("version", version)
+	d.Set("modules_model", modules)
+
+	_, err = waitUntilSwitchManaged(dcnmClient, fabricName, newSerial, d.Get("config_timeout").(int)*60)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = setSwitchMaintenanceMode(dcnmClient, fabricName, newSerial, false)
+	if err != nil {
+		return diag.Errorf("error at moving switch %s to normal mode: %s", newSerial, err)
+	}
+
+	if d.Get("deploy").(bool) {
+		err = deployFabric(dcnmClient, fabricName)
+		if err != nil {
+			return diag.Errorf("error at fabric deployment after replacement: %s", err)
+		}
+	}
+
+	log.Println("[DEBUG] End of Create method ", d.Id())
+	return resourceDCNMSwitchRMARead(ctx, d, m)
+}
+
+func resourceDCNMSwitchRMARead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Read method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+
+	idInfo := strings.Split(d.Id(), ":")
+	if len(idInfo) != 2 {
+		return diag.Errorf("invalid id %s for switch replacement", d.Id())
+	}
+
+	cont, err := getRemoteSwitch(dcnmClient, idInfo[0], "", idInfo[1])
+	if err == errSwitchNotFound {
+		log.Printf("[DEBUG] replacement switch %s not found", idInfo[1])
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("ip", models.G(cont, "ipAddress"))
+	d.Set("switch_name", models.G(cont, "logicalName"))
+
+	log.Println("[DEBUG] End of Read method ", d.Id())
+	return nil
+}
+
+func resourceDCNMSwitchRMADelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Delete method ", d.Id())
+
+	// A replacement can not be reverted, the switch stays in the fabric and
+	// is only removed from the state.
+	d.SetId("")
+
+	log.Println("[DEBUG] End of Delete method ", d.Id())
+	return nil
+}
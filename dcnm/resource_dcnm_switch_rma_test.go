@@ -0,0 +1,68 @@
+package dcnm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var providerfSwitchRMA *schema.Provider
+
+func TestAccDCNMSwitchRMA_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactoriesInternal(&providerfSwitchRMA),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDCNMSwitchRMAConfig_basic("9Y0K4YPFFOA", "9Y0K4YPFRMA"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMSwitchRMAExists("dcnm_switch_rma.test", "9Y0K4YPFRMA"),
+					resource.TestCheckResourceAttr("dcnm_switch_rma.test", "ip", "172.25.74.93"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDCNMSwitchRMAConfig_basic(oldSerial, newSerial string) string {
+	return fmt.Sprintf(`
+	resource "dcnm_switch_rma" "test" {
+		fabric_name       = "fab2"
+		old_serial_number = "%s"
+		new_serial_number = "%s"
+		password          = "ins3965!"
+		gateway           = "172.25.74.1/24"
+	}
+	`, oldSerial, newSerial)
+}
+
+func testAccCheckDCNMSwitchRMAExists(name, serial string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+
+		if !ok {
+			return fmt.Errorf("Switch RMA %s not found", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Switch RMA dn was set")
+		}
+
+		dcnmClient := (*providerfSwitchRMA).Meta().(*client.Client)
+
+		cont, err := getRemoteSwitch(dcnmClient, "fab2", "", serial)
+		if err != nil {
+			return err
+		}
+
+		if mode := models.G(cont, "mode"); mode == "Maintenance" {
+			return fmt.Errorf("Bad Switch mode %s", mode)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,74 @@
+package dcnm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var providerfSwitch *schema.Provider
+
+func TestAccDCNMSwitch_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactoriesInternal(&providerfSwitch),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDCNMSwitchConfig_basic("leaf", "normal"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMSwitchExists("dcnm_switch.test", "Normal"),
+					resource.TestCheckResourceAttr("dcnm_switch.test", "role", "leaf"),
+				),
+			},
+			{
+				Config: testAccCheckDCNMSwitchConfig_basic("border", "maintenance"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMSwitchExists("dcnm_switch.test", "Maintenance"),
+					resource.TestCheckResourceAttr("dcnm_switch.test", "role", "border"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDCNMSwitchConfig_basic(role, mode string) string {
+	return fmt.Sprintf(`
+	resource "dcnm_switch" "test" {
+		fabric_name   = "fab2"
+		serial_number = "9Y0K4YPFFOA"
+		role          = "%s"
+		mode          = "%s"
+	}
+	`, role, mode)
+}
+
+func testAccCheckDCNMSwitchExists(name, mode string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+
+		if !ok {
+			return fmt.Errorf("Switch %s not found", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Switch dn was set")
+		}
+
+		dcnmClient := (*providerfSwitch).Meta().(*client.Client)
+
+		cont, err := getRemoteSwitch(dcnmClient, "fab2", "", "9Y0K4YPFFOA")
+		if err != nil {
+			return err
+		}
+
+		if modeGet := models.G(cont, "mode"); modeGet != mode {
+			return fmt.Errorf("Bad Switch mode %s", modeGet)
+		}
+		return nil
+	}
+}
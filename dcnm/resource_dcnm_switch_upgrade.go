@@ -0,0 +1,380 @@
+package dcnm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/container"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceDCNMSwitchUpgrade() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDCNMSwitchUpgradeCreate,
+		ReadContext:   resourceDCNMSwitchUpgradeRead,
+		DeleteContext: resourceDCNMSwitchUpgradeDelete,
+
+		Schema: map[string]*schema.Schema{
+			"fabric_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"serial_numbers": {
+				Type:     schema.TypeSet,
+				Required: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"upgrade_mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "disruptive",
+				ValidateFunc: validation.StringInSlice([]string{
+					"disruptive",
+					"issu",
+				}, false),
+			},
+
+			"epld_upgrade": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+
+			"upgrade_timeout": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Default:  60,
+			},
+
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"switch_status": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"serial_number": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"switch_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"policy_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"image_staged": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"validated": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"upgrade": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getImageStatusForSwitches(dcnmClient *client.Client, serials []string) (map[string]*container.Container, error) {
+	statusConts, err := getImageStatus(dcnmClient)
+	if err != nil {
+		return nil, err
+	}
+
+	statusMap := make(map[string]*container.Container)
+	for _, cont := range statusConts {
+		serial := models.G(cont, "serialNumber")
+		for _, target := range serials {
+			if serial == target {
+				statusMap[serial] = cont
+				break
+			}
+		}
+	}
+	return statusMap, nil
+}
+
+// staleImageOperations records the switches whose operation field already
+// reports a final Success or Failed before a new request is sent. NDFC keeps
+// that result from an earlier run until it picks up the new request.
+func staleImageOperations(statusMap map[string]*container.Container, field string) map[string]bool {
+	stale := make(map[string]bool)
+	for serial, cont := range statusMap {
+		if status := models.G(cont, field); status == "Success" || status == "Failed" {
+			stale[serial] = true
+		}
+	}
+	return stale
+}
+
+// imageOperationDone reports whether the operation field reports Success for
+// all the switches, and fails for the first switch reporting Failed. The result
+// of a switch in stale is left over from an earlier run and skipped, until the
+// switch reports another status and is removed from stale.
+func imageOperationDone(statusMap map[string]*container.Container, serials []string, field string, stale map[string]bool) (bool, error) {
+	done := true
+	for _, serial := range serials {
+		cont, ok := statusMap[serial]
+		if !ok {
+			return false, fmt.Errorf("switch %s is not known to image management", serial)
+		}
+
+		status := models.G(cont, field)
+		if status != "Success" && status != "Failed" {
+			delete(stale, serial)
+			done = false
+			continue
+		}
+		if stale[serial] {
+			done = false
+			continue
+		}
+		if status == "Failed" {
+			return false, fmt.Errorf("%s failed for switch %s (%s)", field, models.G(cont, "deviceName"), serial)
+		}
+	}
+	return done, nil
+}
+
+// waitForImageOperation polls the image management status of the switches
+// until the given operation field reports Success for all of them. It fails
+// as soon as one switch reports Failed. stale holds the switches whose result
+// was already final before the request, see staleImageOperations.
+func waitForImageOperation(dcnmClient *client.Client, serials []string, field string, stale map[string]bool, timeout int) error {
+	initTime := time.Now()
+	for time.Since(initTime) < (time.Duration(timeout) * time.Second) {
+		time.Sleep(15 * time.Second)
+
+		statusMap, err := getImageStatusForSwitches(dcnmClient, serials)
+		if err != nil {
+			log.Println("Error at get call for image management status :", err)
+			continue
+		}
+
+		done, err := imageOperationDone(statusMap, serials, field, stale)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+	}
+	return fmt.Errorf("timeout occurs before %s finished for switches %s", field, strings.Join(serials, ","))
+}
+
+func getSwitchUpgradePayload(d *schema.ResourceData, statusMap map[string]*container.Container, serials []string) (map[string]interface{}, error) {
+	devices := make([]map[string]interface{}, 0, len(serials))
+	for _, serial := range serials {
+		policyName := ""
+		if cont, ok := statusMap[serial]; ok {
+			policyName = models.G(cont, "policy")
+		}
+		if policyName == "" || policyName == "None" {
+			return nil, fmt.Errorf("no image policy is attached to switch %s", serial)
+		}
+		devices = append(devices, map[string]interface{}{
+			"serialNumber": serial,
+			"policyName":   policyName,
+		})
+	}
+
+	issu := d.Get("upgrade_mode").(string) == "issu"
+	return map[string]interface{}{
+		"devices":     devices,
+		"issuUpgrade": true,
+		"issuUpgradeOptions1": map[string]interface{}{
+			"nonDisruptive":      issu,
+			"forceNonDisruptive": false,
+			"disruptive":         !issu,
+		},
+		"issuUpgradeOptions2": map[string]interface{}{
+			"biosForce": false,
+		},
+		"epldUpgrade": d.Get("epld_upgrade").(bool),
+		"epldOptions": map[string]interface{}{
+			"moduleNumber": "ALL",
+			"golden":       false,
+		},
+		"reboot": false,
+		"rebootOptions": map[string]interface{}{
+			"configReload": false,
+			"writeErase":   false,
+		},
+		"pacakgeInstall":   false,
+		"pacakgeUnInstall": false,
+	}, nil
+}
+
+func resourceDCNMSwitchUpgradeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Create method ")
+
+	dcnmClient := m.(*client.Client)
+	fabricName := d.Get("fabric_name").(string)
+	timeout := d.Get("upgrade_timeout").(int) * 60
+	urls := ImageURLS[dcnmClient.GetPlatform()]
+
+	serials := interfaceToStrList(d.Get("serial_numbers").(*schema.Set).List())
+	sort.Strings(serials)
+
+	for _, serial := range serials {
+		if _, err := getRemoteSwitch(dcnmClient, fabricName, "", serial); err != nil {
+			return diag.Errorf("switch %s is not found in fabric %s", serial, fabricName)
+		}
+	}
+
+	statusMap, err := getImageStatusForSwitches(dcnmClient, serials)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	payload, err := getSwitchUpgradePayload(d, statusMap, serials)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	stale := staleImageOperations(statusMap, "imageStaged")
+
+	// NDFC expects the misspelled key for the staging request
+	_, err = makeAndDoRequest(dcnmClient, "POST", urls["Stage"], map[string]interface{}{
+		"sereialNum": serials,
+	}, true)
+	if err != nil {
+		return diag.Errorf("error at image staging: %s", err)
+	}
+	err = waitForImageOperation(dcnmClient, serials, "imageStaged", stale, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	statusMap, err = getImageStatusForSwitches(dcnmClient, serials)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	stale = staleImageOperations(statusMap, "validated")
+
+	_, err = makeAndDoRequest(dcnmClient, "POST", urls["Validate"], map[string]interface{}{
+		"serialNum":     serials,
+		"nonDisruptive": d.Get("upgrade_mode").(string) == "issu",
+	}, true)
+	if err != nil {
+		return diag.Errorf("error at image validation: %s", err)
+	}
+	err = waitForImageOperation(dcnmClient, serials, "validated", stale, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	statusMap, err = getImageStatusForSwitches(dcnmClient, serials)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	stale = staleImageOperations(statusMap, "upgrade")
+
+	_, err = makeAndDoRequest(dcnmClient, "POST", urls["Upgrade"], payload, true)
+	if err != nil {
+		return diag.Errorf("error at image upgrade: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", fabricName, strings.Join(serials, ",")))
+
+	err = waitForImageOperation(dcnmClient, serials, "upgrade", stale, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("[DEBUG] End of Create method ", d.Id())
+	return resourceDCNMSwitchUpgradeRead(ctx, d, m)
+}
+
+func resourceDCNMSwitchUpgradeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Read method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+
+	serials := interfaceToStrList(d.Get("serial_numbers").(*schema.Set).List())
+	sort.Strings(serials)
+
+	statusMap, err := getImageStatusForSwitches(dcnmClient, serials)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(statusMap) == 0 {
+		log.Printf("[DEBUG] switches of upgrade %s not found", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	switchStatus := make([]interface{}, 0, len(statusMap))
+	for _, serial := range serials {
+		cont, ok := statusMap[serial]
+		if !ok {
+			continue
+		}
+		switchStatus = append(switchStatus, map[string]interface{}{
+			"serial_number": serial,
+			"switch_name":   models.G(cont, "deviceName"),
+			"policy_name":   models.G(cont, "policy"),
+			"image_staged":  models.G(cont, "imageStaged"),
+			"validated":     models.G(cont, "validated"),
+			"upgrade":       models.G(cont, "upgrade"),
+			"status":        models.G(cont, "status"),
+		})
+	}
+	d.Set("switch_status", switchStatus)
+
+	log.Println("[DEBUG] End of Read method ", d.Id())
+	return nil
+}
+
+func resourceDCNMSwitchUpgradeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Delete method ", d.Id())
+
+	// An upgrade can not be rolled back, the switches keep their running
+	// image and the resource is only removed from the state.
+	d.SetId("")
+
+	log.Println("[DEBUG] End of Delete method ", d.Id())
+	return nil
+}
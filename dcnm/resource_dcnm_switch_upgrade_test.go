@@ -0,0 +1,185 @@
+package dcnm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ciscoecosystem/dcnm-go-client/container"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var providerfSwitchUpgrade *schema.Provider
+
+func testImageStatusMap(t *testing.T, raw string) map[string]*container.Container {
+	cont, err := container.ParseJSON([]byte(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statusMap := make(map[string]*container.Container)
+	for _, status := range cont.Children() {
+		statusMap[models.G(status, "serialNumber")] = status
+	}
+	return statusMap
+}
+
+func TestImageOperationDone(t *testing.T) {
+	serials := []string{"9A1", "9A2"}
+	before := testImageStatusMap(t, `[
+		{"serialNumber":"9A1","deviceName":"leaf1","imageStaged":"Success"},
+		{"serialNumber":"9A2","deviceName":"leaf2","imageStaged":"Failed"}
+	]`)
+	stale := staleImageOperations(before, "imageStaged")
+	if !stale["9A1"] || !stale["9A2"] {
+		t.Fatalf("expected both switches to be stale, got %v", stale)
+	}
+
+	polls := []struct {
+		name     string
+		raw      string
+		expected bool
+		fails    bool
+	}{
+		{
+			name: "request not picked up yet",
+			raw: `[
+				{"serialNumber":"9A1","deviceName":"leaf1","imageStaged":"Success"},
+				{"serialNumber":"9A2","deviceName":"leaf2","imageStaged":"Failed"}
+			]`,
+		},
+		{
+			name: "operation in progress",
+			raw: `[
+				{"serialNumber":"9A1","deviceName":"leaf1","imageStaged":"In-Progress"},
+				{"serialNumber":"9A2","deviceName":"leaf2","imageStaged":"Failed"}
+			]`,
+		},
+		{
+			name: "one switch done",
+			raw: `[
+				{"serialNumber":"9A1","deviceName":"leaf1","imageStaged":"Success"},
+				{"serialNumber":"9A2","deviceName":"leaf2","imageStaged":"In-Progress"}
+			]`,
+		},
+		{
+			name: "all switches done",
+			raw: `[
+				{"serialNumber":"9A1","deviceName":"leaf1","imageStaged":"Success"},
+				{"serialNumber":"9A2","deviceName":"leaf2","imageStaged":"Success"}
+			]`,
+			expected: true,
+		},
+	}
+	for _, poll := range polls {
+		done, err := imageOperationDone(testImageStatusMap(t, poll.raw), serials, "imageStaged", stale)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", poll.name, err)
+		}
+		if done != poll.expected {
+			t.Errorf("%s: expected done %v, got %v", poll.name, poll.expected, done)
+		}
+	}
+}
+
+func TestImageOperationDoneFailed(t *testing.T) {
+	serials := []string{"9A1"}
+	failed := testImageStatusMap(t, `[{"serialNumber":"9A1","deviceName":"leaf1","validated":"Failed"}]`)
+
+	if _, err := imageOperationDone(failed, serials, "validated", map[string]bool{}); err == nil {
+		t.Error("expected an error for a failed validation")
+	}
+
+	stale := map[string]bool{"9A1": true}
+	if done, err := imageOperationDone(failed, serials, "validated", stale); done || err != nil {
+		t.Errorf("expected a stale failure to be skipped, got done %v, error %v", done, err)
+	}
+
+	if _, err := imageOperationDone(failed, []string{"9A3"}, "validated", map[string]bool{}); err == nil {
+		t.Error("expected an error for a switch unknown to image management")
+	}
+}
+
+func TestGetSwitchUpgradePayload(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDCNMSwitchUpgrade().Schema, map[string]interface{}{
+		"fabric_name":    "fab2",
+		"serial_numbers": []interface{}{"9A1"},
+		"upgrade_mode":   "issu",
+	})
+
+	statusMap := testImageStatusMap(t, `[
+		{"serialNumber":"9A1","policy":"n9k"},
+		{"serialNumber":"9A2","policy":"None"}
+	]`)
+
+	payload, err := getSwitchUpgradePayload(d, statusMap, []string{"9A1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	devices := payload["devices"].([]map[string]interface{})
+	if len(devices) != 1 || devices[0]["policyName"] != "n9k" {
+		t.Errorf("unexpected devices %v", devices)
+	}
+	options := payload["issuUpgradeOptions1"].(map[string]interface{})
+	if options["nonDisruptive"] != true || options["disruptive"] != false {
+		t.Errorf("unexpected upgrade options %v", options)
+	}
+
+	if _, err := getSwitchUpgradePayload(d, statusMap, []string{"9A2"}); err == nil {
+		t.Error("expected an error for a switch without image policy")
+	}
+}
+
+func TestAccDCNMSwitchUpgrade_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactoriesInternal(&providerfSwitchUpgrade),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDCNMSwitchUpgradeConfig_basic(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMSwitchUpgradeExists("dcnm_switch_upgrade.test"),
+					resource.TestCheckResourceAttr("dcnm_switch_upgrade.test", "switch_status.0.upgrade", "Success"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDCNMSwitchUpgradeConfig_basic() string {
+	return `
+	resource "dcnm_image_policy" "test" {
+		name         = "acctest_policy"
+		platform     = "N9K"
+		nxos_version = "9.3.8_nxos64-cs_64bit"
+	}
+
+	resource "dcnm_image_policy_attachment" "test" {
+		fabric_name    = "fab2"
+		policy_name    = dcnm_image_policy.test.name
+		serial_numbers = ["9Y0K4YPFFOA"]
+	}
+
+	resource "dcnm_switch_upgrade" "test" {
+		fabric_name    = "fab2"
+		serial_numbers = dcnm_image_policy_attachment.test.serial_numbers
+	}
+	`
+}
+
+func testAccCheckDCNMSwitchUpgradeExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+
+		if !ok {
+			return fmt.Errorf("Switch upgrade %s not found", name)
+		}
+
+		if rs.Primary.ID != "fab2:9Y0K4YPFFOA" {
+			return fmt.Errorf("Bad Switch upgrade id %s", rs.Primary.ID)
+		}
+		return nil
+	}
+}
@@ -70,11 +70,13 @@ var TemplateURLS = map[string]map[string]string{
 		"Create":   "/rest/config/templates/template?templateName=%s",
 		"Common":   "/rest/config/templates/%s",
 		"Validate": "/rest/config/templates/validate",
+		"List":     "/rest/config/templates",
 	},
 	"nd": {
 		"Create":   "/appcenter/cisco/ndfc/api/v1/configtemplate/rest/config/templates/template?templateName=%s",
 		"Common":   "/appcenter/cisco/ndfc/api/v1/configtemplate/rest/config/templates/%s",
 		"Validate": "/configtemplate/rest/config/templates/validate",
+		"List":     "/appcenter/cisco/ndfc/api/v1/configtemplate/rest/config/templates",
 	},
 }
 
@@ -0,0 +1,239 @@
+package dcnm
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/container"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var UserURLS = map[string]map[string]string{
+	"dcnm": {
+		"Create": "/rest/security/users",
+		"Common": "/rest/security/users/%s",
+	},
+	"nd": {
+		"Create":       "/nexus/infra/api/aaa/v4/localusers",
+		"Common":       "/nexus/infra/api/aaa/v4/localusers/%s",
+		"DomainCreate": "/nexus/infra/api/aaa/v4/securitydomains",
+		"Domain":       "/nexus/infra/api/aaa/v4/securitydomains/%s",
+	},
+}
+
+// userNameKeys holds the attribute carrying the login name of a local user,
+// the remaining user attributes share their names across platforms.
+var userNameKeys = map[string]string{
+	"dcnm": "userName",
+	"nd":   "loginID",
+}
+
+func resourceDCNMUser() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDCNMUserCreate,
+		UpdateContext: resourceDCNMUserUpdate,
+		ReadContext:   resourceDCNMUserRead,
+		DeleteContext: resourceDCNMUserDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: resourceDCNMUserImporter,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"password": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+
+			"first_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"last_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"email": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+// getRemoteUser returns the local user object, unwrapping the spec envelope
+// used by ND.
+func getRemoteUser(dcnmClient *client.Client, name string) (*container.Container, error) {
+	durl := fmt.Sprintf(UserURLS[dcnmClient.GetPlatform()]["Common"], name)
+	cont, err := makeAndDoRequest(dcnmClient, "GET", durl, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	if cont.Exists("spec") {
+		cont = cont.S("spec")
+	}
+	if models.G(cont, userNameKeys[dcnmClient.GetPlatform()]) != name {
+		return nil, fmt.Errorf("user %s not found", name)
+	}
+	return cont, nil
+}
+
+// userWriteKeys holds the attributes accepted when a local user is written,
+// anything else returned by the controller is read-only.
+var userWriteKeys = map[string][]string{
+	"dcnm": {"userName", "password", "firstName", "lastName", "email", "role", "accessibleFabrics"},
+	"nd":   {"loginID", "password", "firstName", "lastName", "email", "rbac"},
+}
+
+// userPayload builds the body sent on creation and update of a local user from
+// a user object, so both requests carry the same envelope.
+func userPayload(platform string, cont *container.Container) map[string]interface{} {
+	payload := make(map[string]interface{})
+	for _, key := range userWriteKeys[platform] {
+		if cont.Exists(key) {
+			payload[key] = cont.S(key).Data()
+		}
+	}
+	return payload
+}
+
+func updateRemoteUser(dcnmClient *client.Client, name string, cont *container.Container) error {
+	platform := dcnmClient.GetPlatform()
+	durl := fmt.Sprintf(UserURLS[platform]["Common"], name)
+	_, err := makeAndDoRequest(dcnmClient, "PUT", durl, userPayload(platform, cont), true)
+	return err
+}
+
+func setUserAttributes(d *schema.ResourceData, dcnmClient *client.Client, cont *container.Container) *schema.ResourceData {
+	d.Set("name", models.G(cont, userNameKeys[dcnmClient.GetPlatform()]))
+	d.Set("first_name", models.G(cont, "firstName"))
+	d.Set("last_name", models.G(cont, "lastName"))
+	d.Set("email", models.G(cont, "email"))
+
+	d.SetId(d.Get("name").(string))
+	return d
+}
+
+func resourceDCNMUserImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	log.Println("[DEBUG] Begining Importer ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+
+	cont, err := getRemoteUser(dcnmClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	stateImport := setUserAttributes(d, dcnmClient, cont)
+
+	log.Println("[DEBUG] End of Importer ", d.Id())
+	return []*schema.ResourceData{stateImport}, nil
+}
+
+func resourceDCNMUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Create method ")
+
+	dcnmClient := m.(*client.Client)
+	platform := dcnmClient.GetPlatform()
+	name := d.Get("name").(string)
+
+	cont := container.New()
+	cont.Set(name, userNameKeys[platform])
+	cont.Set(d.Get("password").(string), "password")
+	cont.Set(d.Get("first_name").(string), "firstName")
+	cont.Set(d.Get("last_name").(string), "lastName")
+	cont.Set(d.Get("email").(string), "email")
+	if platform == "nd" {
+		cont.Set(map[string]interface{}{}, "rbac", "domains")
+	} else {
+		// DCNM requires a role at creation, dcnm_user_role manages it later on
+		cont.Set("network-operator", "role")
+	}
+
+	_, err := makeAndDoRequest(dcnmClient, "POST", UserURLS[platform]["Create"], userPayload(platform, cont), true)
+	if err != nil {
+		return diag.Errorf("error at creation of user %s: %s", name, err)
+	}
+
+	d.SetId(name)
+	log.Println("[DEBUG] End of Create method ", d.Id())
+	return resourceDCNMUserRead(ctx, d, m)
+}
+
+func resourceDCNMUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Update method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+
+	// The user object is written back as a whole, so role assignments managed
+	// by dcnm_user_role are kept as they are on the controller.
+	cont, err := getRemoteUser(dcnmClient, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	cont.Set(d.Get("first_name").(string), "firstName")
+	cont.Set(d.Get("last_name").(string), "lastName")
+	cont.Set(d.Get("email").(string), "email")
+	if d.HasChange("password") {
+		cont.Set(d.Get("password").(string), "password")
+	} else {
+		cont.Delete("password")
+	}
+
+	err = updateRemoteUser(dcnmClient, d.Id(), cont)
+	if err != nil {
+		return diag.Errorf("error at update of user %s: %s", d.Id(), err)
+	}
+
+	log.Println("[DEBUG] End of Update method ", d.Id())
+	return resourceDCNMUserRead(ctx, d, m)
+}
+
+func resourceDCNMUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Read method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+
+	cont, err := getRemoteUser(dcnmClient, d.Id())
+	if err != nil {
+		log.Printf("[DEBUG] user %s not found: %s", d.Id(), err)
+		d.SetId("")
+		return nil
+	}
+
+	// The password is write-only and never returned by the controller
+	setUserAttributes(d, dcnmClient, cont)
+
+	log.Println("[DEBUG] End of Read method ", d.Id())
+	return nil
+}
+
+func resourceDCNMUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Delete method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+
+	durl := fmt.Sprintf(UserURLS[dcnmClient.GetPlatform()]["Common"], d.Id())
+	_, err := makeAndDoRequest(dcnmClient, "DELETE", durl, nil, true)
+	if err != nil {
+		return diag.Errorf("error at deletion of user %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	log.Println("[DEBUG] End of Delete method ", d.Id())
+	return nil
+}
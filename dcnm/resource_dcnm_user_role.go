@@ -0,0 +1,257 @@
+package dcnm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/container"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var userPrivileges = map[string]string{
+	"read":  "ReadPriv",
+	"write": "WritePriv",
+}
+
+func resourceDCNMUserRole() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDCNMUserRoleCreate,
+		UpdateContext: resourceDCNMUserRoleUpdate,
+		ReadContext:   resourceDCNMUserRoleRead,
+		DeleteContext: resourceDCNMUserRoleDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: resourceDCNMUserRoleImporter,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"user_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"security_domain": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "all",
+			},
+
+			"roles": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"privilege": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "write",
+				ValidateFunc: validation.StringInSlice([]string{
+					"read",
+					"write",
+				}, false),
+			},
+
+			"fabrics": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// setUserRoles applies the roles of the resource to the user object. ND keeps
+// roles per security domain, DCNM has a single role and a list of fabrics the
+// user has access to.
+func setUserRoles(d *schema.ResourceData, dcnmClient *client.Client, cont *container.Container) error {
+	roles := interfaceToStrList(d.Get("roles"))
+
+	if dcnmClient.GetPlatform() == "nd" {
+		rolePrivs := make([]interface{}, 0, len(roles))
+		for _, role := range roles {
+			rolePrivs = append(rolePrivs, []interface{}{role, userPrivileges[d.Get("privilege").(string)]})
+		}
+		_, err := cont.Set(rolePrivs, "rbac", "domains", d.Get("security_domain").(string), "roles")
+		return err
+	}
+
+	if len(roles) != 1 {
+		return fmt.Errorf("exactly one role is supported for DCNM users")
+	}
+	if _, err := cont.Set(roles[0], "role"); err != nil {
+		return err
+	}
+	_, err := cont.Set(interfaceToStrList(d.Get("fabrics")), "accessibleFabrics")
+	return err
+}
+
+func applyUserRoles(d *schema.ResourceData, dcnmClient *client.Client) error {
+	userName := d.Get("user_name").(string)
+
+	cont, err := getRemoteUser(dcnmClient, userName)
+	if err != nil {
+		return err
+	}
+
+	if err := setUserRoles(d, dcnmClient, cont); err != nil {
+		return err
+	}
+	cont.Delete("password")
+
+	return updateRemoteUser(dcnmClient, userName, cont)
+}
+
+func setUserRoleAttributes(d *schema.ResourceData, dcnmClient *client.Client, cont *container.Container) (*schema.ResourceData, error) {
+	domain := d.Get("security_domain").(string)
+
+	if dcnmClient.GetPlatform() == "nd" {
+		roleConts := cont.S("rbac", "domains", domain, "roles").Children()
+		if len(roleConts) == 0 {
+			return d, fmt.Errorf("user %s has no roles in security domain %s", d.Get("user_name").(string), domain)
+		}
+
+		roles := make([]string, 0, len(roleConts))
+		for _, roleCont := range roleConts {
+			roles = append(roles, stripQuotes(roleCont.Index(0).String()))
+			if stripQuotes(roleCont.Index(1).String()) == userPrivileges["read"] {
+				d.Set("privilege", "read")
+			} else {
+				d.Set("privilege", "write")
+			}
+		}
+		d.Set("roles", roles)
+	} else {
+		d.Set("roles", []string{models.G(cont, "role")})
+		if cont.Exists("accessibleFabrics") {
+			d.Set("fabrics", interfaceToStrList(cont.S("accessibleFabrics").Data()))
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", d.Get("user_name").(string), domain))
+	return d, nil
+}
+
+func resourceDCNMUserRoleImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	log.Println("[DEBUG] Begining Importer ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+	importInfo := strings.Split(d.Id(), ":")
+	if len(importInfo) != 2 {
+		return nil, fmt.Errorf("not getting enough arguments for the import operation")
+	}
+
+	cont, err := getRemoteUser(dcnmClient, importInfo[0])
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("user_name", importInfo[0])
+	d.Set("security_domain", importInfo[1])
+	stateImport, err := setUserRoleAttributes(d, dcnmClient, cont)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Println("[DEBUG] End of Importer ", d.Id())
+	return []*schema.ResourceData{stateImport}, nil
+}
+
+func resourceDCNMUserRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Create method ")
+
+	dcnmClient := m.(*client.Client)
+
+	err := applyUserRoles(d, dcnmClient)
+	if err != nil {
+		return diag.Errorf("error at role assignment of user %s: %s", d.Get("user_name").(string), err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", d.Get("user_name").(string), d.Get("security_domain").(string)))
+	log.Println("[DEBUG] End of Create method ", d.Id())
+	return resourceDCNMUserRoleRead(ctx, d, m)
+}
+
+func resourceDCNMUserRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Update method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+
+	if d.HasChanges("roles", "privilege", "fabrics") {
+		err := applyUserRoles(d, dcnmClient)
+		if err != nil {
+			return diag.Errorf("error at role assignment of user %s: %s", d.Get("user_name").(string), err)
+		}
+	}
+
+	log.Println("[DEBUG] End of Update method ", d.Id())
+	return resourceDCNMUserRoleRead(ctx, d, m)
+}
+
+func resourceDCNMUserRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Read method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+
+	cont, err := getRemoteUser(dcnmClient, d.Get("user_name").(string))
+	if err != nil {
+		log.Printf("[DEBUG] user of role assignment %s not found: %s", d.Id(), err)
+		d.SetId("")
+		return nil
+	}
+
+	_, err = setUserRoleAttributes(d, dcnmClient, cont)
+	if err != nil {
+		log.Printf("[DEBUG] role assignment %s not found: %s", d.Id(), err)
+		d.SetId("")
+		return nil
+	}
+
+	log.Println("[DEBUG] End of Read method ", d.Id())
+	return nil
+}
+
+func resourceDCNMUserRoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Begining Delete method ", d.Id())
+
+	dcnmClient := m.(*client.Client)
+	userName := d.Get("user_name").(string)
+
+	cont, err := getRemoteUser(dcnmClient, userName)
+	if err != nil {
+		// the user itself is already gone
+		d.SetId("")
+		return nil
+	}
+
+	if dcnmClient.GetPlatform() == "nd" {
+		cont.Delete("rbac", "domains", d.Get("security_domain").(string))
+	} else {
+		// DCNM users always need a role, fall back to the least privileged one
+		cont.Set("network-operator", "role")
+		cont.Set([]string{}, "accessibleFabrics")
+	}
+	cont.Delete("password")
+
+	err = updateRemoteUser(dcnmClient, userName, cont)
+	if err != nil {
+		return diag.Errorf("error at removing roles of user %s: %s", userName, err)
+	}
+
+	d.SetId("")
+	log.Println("[DEBUG] End of Delete method ", d.Id())
+	return nil
+}
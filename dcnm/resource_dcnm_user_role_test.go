@@ -0,0 +1,69 @@
+package dcnm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var providerfUserRole *schema.Provider
+
+func TestAccDCNMUserRole_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactoriesInternal(&providerfUserRole),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDCNMUserRoleConfig_basic("network-operator"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMUserRoleExists("dcnm_user_role.test"),
+					resource.TestCheckResourceAttr("dcnm_user_role.test", "roles.0", "network-operator"),
+				),
+			},
+			{
+				Config: testAccCheckDCNMUserRoleConfig_basic("network-admin"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMUserRoleExists("dcnm_user_role.test"),
+					resource.TestCheckResourceAttr("dcnm_user_role.test", "roles.0", "network-admin"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDCNMUserRoleConfig_basic(role string) string {
+	return fmt.Sprintf(`
+	resource "dcnm_user" "test" {
+		name     = "acctest_user"
+		password = "Acctest@Pass123"
+	}
+
+	resource "dcnm_user_role" "test" {
+		user_name = dcnm_user.test.name
+		roles     = ["%s"]
+	}
+	`, role)
+}
+
+func testAccCheckDCNMUserRoleExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+
+		if !ok {
+			return fmt.Errorf("User role %s not found", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No User role dn was set")
+		}
+
+		dcnmClient := (*providerfUserRole).Meta().(*client.Client)
+
+		_, err := getRemoteUser(dcnmClient, rs.Primary.Attributes["user_name"])
+		return err
+	}
+}
@@ -0,0 +1,121 @@
+package dcnm
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ciscoecosystem/dcnm-go-client/client"
+	"github.com/ciscoecosystem/dcnm-go-client/container"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var providerfUser *schema.Provider
+
+func TestAccDCNMUser_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactoriesInternal(&providerfUser),
+		CheckDestroy:      testAccCheckDCNMUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDCNMUserConfig_basic("first"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMUserExists("dcnm_user.test", "first"),
+				),
+			},
+			{
+				Config: testAccCheckDCNMUserConfig_basic("second"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMUserExists("dcnm_user.test", "second"),
+				),
+			},
+		},
+	})
+}
+
+func TestUserPayloadND(t *testing.T) {
+	// ND returns the user in a spec envelope next to read-only state, the update
+	// has to be sent with the same attributes as the creation.
+	cont, _ := container.ParseJSON([]byte(`{
+		"spec": {
+			"loginID": "acctest_user",
+			"firstName": "first",
+			"lastName": "operator",
+			"email": "",
+			"accountStatus": "Active",
+			"passwordPolicy": {"reuseLimitation": 0, "timeIntervalLimitation": 0},
+			"rbac": {"domains": {"all": {"roles": [["fabric-admin", "WritePriv"]]}}}
+		},
+		"status": {"lastLogin": "2024-01-01T00:00:00Z"}
+	}`))
+	cont = cont.S("spec")
+	cont.Set("second", "firstName")
+
+	payload := userPayload("nd", cont)
+
+	expected := map[string]interface{}{
+		"loginID":   "acctest_user",
+		"firstName": "second",
+		"lastName":  "operator",
+		"email":     "",
+		"rbac":      cont.S("rbac").Data(),
+	}
+	if !reflect.DeepEqual(payload, expected) {
+		t.Errorf("expected %v, got %v", expected, payload)
+	}
+}
+
+func testAccCheckDCNMUserConfig_basic(firstName string) string {
+	return fmt.Sprintf(`
+	resource "dcnm_user" "test" {
+		name       = "acctest_user"
+		password   = "Acctest@Pass123"
+		first_name = "%s"
+		last_name  = "operator"
+	}
+	`, firstName)
+}
+
+func testAccCheckDCNMUserExists(name, firstName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+
+		if !ok {
+			return fmt.Errorf("User %s not found", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No User dn was set")
+		}
+
+		dcnmClient := (*providerfUser).Meta().(*client.Client)
+
+		cont, err := getRemoteUser(dcnmClient, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if firstNameGet := models.G(cont, "firstName"); firstNameGet != firstName {
+			return fmt.Errorf("Bad User first name %s", firstNameGet)
+		}
+		return nil
+	}
+}
+
+func testAccCheckDCNMUserDestroy(s *terraform.State) error {
+	dcnmClient := (*providerfUser).Meta().(*client.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "dcnm_user" {
+			_, err := getRemoteUser(dcnmClient, rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("User still exists")
+			}
+		}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package dcnm
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -11,21 +12,31 @@ import (
 	"github.com/ciscoecosystem/dcnm-go-client/client"
 	"github.com/ciscoecosystem/dcnm-go-client/container"
 	"github.com/ciscoecosystem/dcnm-go-client/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceDCNMVRF() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDCNMVRFCreate,
-		Read:   resourceDCNMVRFRead,
-		Update: resourceDCNMVRFUpdate,
-		Delete: resourceDCNMVRFDelete,
+		CreateContext: resourceDCNMVRFCreate,
+		Read:          resourceDCNMVRFRead,
+		Update:        resourceDCNMVRFUpdate,
+		Delete:        resourceDCNMVRFDelete,
 
 		Importer: &schema.ResourceImporter{
 			State: resourceDCNMVRFImporter,
 		},
 
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceDCNMVRFV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceDCNMVRFStateUpgradeV0,
+				Version: 0,
+			},
+		},
+
 		Schema: map[string]*schema.Schema{
 			"fabric_name": &schema.Schema{
 				Type:     schema.TypeString,
@@ -100,23 +111,15 @@ func resourceDCNMVRF() *schema.Resource {
 			},
 
 			"trm_enable": &schema.Schema{
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
 				Computed: true,
-				ValidateFunc: validation.StringInSlice([]string{
-					"true",
-					"false",
-				}, false),
 			},
 
 			"rp_external_flag": &schema.Schema{
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
 				Computed: true,
-				ValidateFunc: validation.StringInSlice([]string{
-					"true",
-					"false",
-				}, false),
 			},
 
 			"rp_address": &schema.Schema{
@@ -144,53 +147,33 @@ func resourceDCNMVRF() *schema.Resource {
 			},
 
 			"ipv6_link_local_flag": &schema.Schema{
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  "true",
-				ValidateFunc: validation.StringInSlice([]string{
-					"true",
-					"false",
-				}, false),
+				Default:  true,
 			},
 
 			"trm_bgw_msite_flag": &schema.Schema{
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
 				Computed: true,
-				ValidateFunc: validation.StringInSlice([]string{
-					"true",
-					"false",
-				}, false),
 			},
 
 			"advertise_host_route": &schema.Schema{
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
 				Computed: true,
-				ValidateFunc: validation.StringInSlice([]string{
-					"true",
-					"false",
-				}, false),
 			},
 
 			"advertise_default_route": &schema.Schema{
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  "true",
-				ValidateFunc: validation.StringInSlice([]string{
-					"true",
-					"false",
-				}, false),
+				Default:  true,
 			},
 
 			"static_default_route": &schema.Schema{
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  "true",
-				ValidateFunc: validation.StringInSlice([]string{
-					"true",
-					"false",
-				}, false),
+				Default:  true,
 			},
 
 			"extension_template": &schema.Schema{
@@ -211,6 +194,15 @@ func resourceDCNMVRF() *schema.Resource {
 				Computed: true,
 			},
 
+			"template_props": &schema.Schema{
+				Type:         schema.TypeMap,
+				Optional:     true,
+				ValidateFunc: validateTemplateProps(templateConfigKeys(models.VRFProfileConfig{})...),
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
 			"deploy": &schema.Schema{
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -223,6 +215,30 @@ func resourceDCNMVRF() *schema.Resource {
 				Default:  300,
 			},
 
+			"on_create_failure": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "partial",
+				ValidateFunc: validation.StringInSlice([]string{
+					"partial",
+					"rollback",
+				}, false),
+			},
+
+			"adopt_existing": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"attached_switches": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
 			"attachments": &schema.Schema{
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -332,6 +348,16 @@ func resourceDCNMVRF() *schema.Resource {
 								},
 							},
 						},
+
+						"deployment_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"failure_reason": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
 					},
 				},
 			},
@@ -367,7 +393,7 @@ func setVRFAttributes(d *schema.ResourceData, cont *container.Container) *schema
 		d.Set("source", stripQuotes(cont.S("source").String()))
 	}
 
-	cont, err := cleanJsonString(stripQuotes(cont.S("vrfTemplateConfig").String()))
+	cont, err := getTemplateConfig(cont, "vrfTemplateConfig")
 	if err == nil {
 		if cont.Exists("mtu") {
 			if mtu, err := strconv.Atoi(stripQuotes(cont.S("mtu").String())); err == nil {
@@ -401,11 +427,11 @@ func setVRFAttributes(d *schema.ResourceData, cont *container.Container) *schema
 				d.Set("max_ibgp_path", ibgp)
 			}
 		}
-		if cont.Exists("trmEnabled") {
-			d.Set("trm_enable", stripQuotes(cont.S("trmEnabled").String()))
+		if flag, err := strconv.ParseBool(stripQuotes(cont.S("trmEnabled").String())); err == nil {
+			d.Set("trm_enable", flag)
 		}
-		if cont.Exists("isRPExternal") {
-			d.Set("rp_external_flag", stripQuotes(cont.S("isRPExternal").String()))
+		if flag, err := strconv.ParseBool(stripQuotes(cont.S("isRPExternal").String())); err == nil {
+			d.Set("rp_external_flag", flag)
 		}
 		if cont.Exists("loopbackNumber") {
 			if loopback, err := strconv.Atoi(stripQuotes(cont.S("loopbackNumber").String())); err == nil {
@@ -418,23 +444,23 @@ func setVRFAttributes(d *schema.ResourceData, cont *container.Container) *schema
 		if cont.Exists("L3VniMcastGroup") {
 			d.Set("mutlicast_address", stripQuotes(cont.S("L3VniMcastGroup").String()))
 		}
-		if cont.Exists("ipv6LinkLocalFlag") {
-			d.Set("ipv6_link_local_flag", stripQuotes(cont.S("ipv6LinkLocalFlag").String()))
+		if flag, err := strconv.ParseBool(stripQuotes(cont.S("ipv6LinkLocalFlag").String())); err == nil {
+			d.Set("ipv6_link_local_flag", flag)
 		}
 		if cont.Exists("multicastGroup") {
 			d.Set("mutlicast_group", stripQuotes(cont.S("multicastGroup").String()))
 		}
-		if cont.Exists("trmBGWMSiteEnabled") {
-			d.Set("trm_bgw_msite_flag", stripQuotes(cont.S("trmBGWMSiteEnabled").String()))
+		if flag, err := strconv.ParseBool(stripQuotes(cont.S("trmBGWMSiteEnabled").String())); err == nil {
+			d.Set("trm_bgw_msite_flag", flag)
 		}
-		if cont.Exists("advertiseHostRouteFlag") {
-			d.Set("advertise_host_route", stripQuotes(cont.S("advertiseHostRouteFlag").String()))
+		if flag, err := strconv.ParseBool(stripQuotes(cont.S("advertiseHostRouteFlag").String())); err == nil {
+			d.Set("advertise_host_route", flag)
 		}
-		if cont.Exists("advertiseDefaultRouteFlag") {
-			d.Set("advertise_default_route", stripQuotes(cont.S("advertiseDefaultRouteFlag").String()))
+		if flag, err := strconv.ParseBool(stripQuotes(cont.S("advertiseDefaultRouteFlag").String())); err == nil {
+			d.Set("advertise_default_route", flag)
 		}
-		if cont.Exists("configureStaticDefaultRouteFlag") {
-			d.Set("static_default_route", stripQuotes(cont.S("configureStaticDefaultRouteFlag").String()))
+		if flag, err := strconv.ParseBool(stripQuotes(cont.S("configureStaticDefaultRouteFlag").String())); err == nil {
+			d.Set("static_default_route", flag)
 		}
 	}
 
@@ -471,7 +497,28 @@ func resourceDCNMVRFImporter(d *schema.ResourceData, m interface{}) ([]*schema.R
 	return []*schema.ResourceData{stateImport}, nil
 }
 
-func resourceDCNMVRFCreate(d *schema.ResourceData, m interface{}) error {
+// setVRFFlags sets the boolean flags of the template config. The computed
+// flags are left out while they are neither configured nor read back, so the
+// template defaults apply to them.
+func setVRFFlags(d *schema.ResourceData, configMap *models.VRFProfileConfig) {
+	if trm, ok := d.GetOkExists("trm_enable"); ok {
+		configMap.TRM = strconv.FormatBool(trm.(bool))
+	}
+	if rpExtr, ok := d.GetOkExists("rp_external_flag"); ok {
+		configMap.RPexternal = strconv.FormatBool(rpExtr.(bool))
+	}
+	if trmbgw, ok := d.GetOkExists("trm_bgw_msite_flag"); ok {
+		configMap.TRMBGW = strconv.FormatBool(trmbgw.(bool))
+	}
+	if hostR, ok := d.GetOkExists("advertise_host_route"); ok {
+		configMap.AdhostRoute = strconv.FormatBool(hostR.(bool))
+	}
+	configMap.IPv6Link = strconv.FormatBool(d.Get("ipv6_link_local_flag").(bool))
+	configMap.AdDefaultRoute = strconv.FormatBool(d.Get("advertise_default_route").(bool))
+	configMap.StaticRoute = strconv.FormatBool(d.Get("static_default_route").(bool))
+}
+
+func resourceDCNMVRFCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Println("[DEBUG] Begining Create method ")
 
 	dcnmClient := m.(*client.Client)
@@ -484,27 +531,40 @@ func resourceDCNMVRFCreate(d *schema.ResourceData, m interface{}) error {
 
 	if deploy, ok := d.GetOk("deploy"); ok && deploy.(bool) == true {
 		if _, ok := d.GetOk("attachments"); !ok {
-			return fmt.Errorf("attachments must be configured if deploy=true")
+			return diag.Errorf("attachments must be configured if deploy=true")
 		}
 	}
 
+	var existing *container.Container
+	if d.Get("adopt_existing").(bool) {
+		if cont, err := getRemoteVRF(dcnmClient, vrf.Fabric, vrf.Name); err == nil && models.G(cont, "vrfName") == vrf.Name {
+			existing = cont
+		}
+	}
+
+	// IDs allocated here are released again on a rollback
+	reservations := make([]resourceReservation, 0)
+
 	if segmentId, ok := d.GetOk("segment_id"); ok {
 		vrf.Id = segmentId.(string)
+	} else if existing != nil {
+		vrf.Id = models.G(existing, "vrfId")
 	} else {
 		//request to get the next vrf segment id
 		if dcnmClient.GetPlatform() == "nd" {
 			cont, err := dcnmClient.GetviaURL(fmt.Sprintf("/rest/top-down/fabrics/%s/vrfinfo", vrf.Fabric))
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 			vrf.Id = cont.S("l3vni").String()
 		} else {
 			cont, err := dcnmClient.GetSegID(fmt.Sprintf("/rest/managed-pool/fabrics/%s/partitions/ids", vrf.Fabric))
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 			vrf.Id = cont.S("partitionSegmentId").String()
 		}
+		reservations = append(reservations, resourceReservation{Pool: "L3_VNI", Value: stripQuotes(vrf.Id)})
 	}
 
 	if srcTemp, ok := d.GetOk("service_template"); ok {
@@ -518,15 +578,20 @@ func resourceDCNMVRFCreate(d *schema.ResourceData, m interface{}) error {
 	configMap := models.VRFProfileConfig{}
 	if vlan, ok := d.GetOk("vlan_id"); ok {
 		configMap.Vlan = vlan.(int)
+	} else if existing != nil {
+		if configCont, err := getTemplateConfig(existing, "vrfTemplateConfig"); err == nil {
+			configMap.Vlan, _ = strconv.Atoi(models.G(configCont, "vrfVlanId"))
+		}
 	} else {
 		durl := fmt.Sprintf("/rest/resource-manager/vlan/%s?vlanUsageType=TOP_DOWN_VRF_VLAN", d.Get("fabric_name").(string))
 		cont, err := dcnmClient.GetviaURL(durl)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		vlan, err := strconv.Atoi(cont.String())
 		if err == nil {
 			configMap.Vlan = vlan
+			reservations = append(reservations, resourceReservation{Pool: "TOP_DOWN_VRF_VLAN", Value: strconv.Itoa(vlan)})
 		}
 	}
 	if mtu, ok := d.GetOk("mtu"); ok {
@@ -550,12 +615,6 @@ func resourceDCNMVRFCreate(d *schema.ResourceData, m interface{}) error {
 	if ibgp, ok := d.GetOk("max_ibgp_path"); ok {
 		configMap.IBGP = ibgp.(int)
 	}
-	if trm, ok := d.GetOk("trm_enable"); ok {
-		configMap.TRM = trm.(string)
-	}
-	if rpExtr, ok := d.GetOk("rp_external_flag"); ok {
-		configMap.RPexternal = rpExtr.(string)
-	}
 	if rpAddr, ok := d.GetOk("rp_address"); ok {
 		configMap.RPaddress = rpAddr.(string)
 	}
@@ -568,276 +627,359 @@ func resourceDCNMVRFCreate(d *schema.ResourceData, m interface{}) error {
 	if mcastGrp, ok := d.GetOk("mutlicast_group"); ok {
 		configMap.Mcastgroup = mcastGrp.(string)
 	}
-	if ipv6, ok := d.GetOk("ipv6_link_local_flag"); ok {
-		configMap.IPv6Link = ipv6.(string)
-	}
-	if trmbgw, ok := d.GetOk("trm_bgw_msite_flag"); ok {
-		configMap.TRMBGW = trmbgw.(string)
-	}
-	if hostR, ok := d.GetOk("advertise_host_route"); ok {
-		configMap.AdhostRoute = hostR.(string)
-	}
-	if defaultR, ok := d.GetOk("advertise_default_route"); ok {
-		configMap.AdDefaultRoute = defaultR.(string)
-	}
-	if staticR, ok := d.GetOk("static_default_route"); ok {
-		configMap.StaticRoute = staticR.(string)
-	}
+	setVRFFlags(d, &configMap)
 	configMap.SegmentID = vrf.Id
 	configMap.VrfName = vrf.Name
 
 	confStr, err := json.Marshal(configMap)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	vrf.Config, err = mergeTemplateProps(confStr, d.Get("template_props").(map[string]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	vrf.Config = string(confStr)
 
-	durl := fmt.Sprintf("/rest/top-down/fabrics/%s/vrfs", vrf.Fabric)
-	_, err = dcnmClient.Save(durl, &vrf)
+	if existing != nil {
+		changes, changesErr := vrfAdoptChanges(&vrf, existing)
+		if changesErr != nil {
+			return diag.FromErr(changesErr)
+		}
+		if len(changes) == 0 {
+			log.Printf("[INFO] Adopting existing VRF %s of fabric %s with segment ID %s, its settings match the configuration", vrf.Name, vrf.Fabric, vrf.Id)
+		} else {
+			log.Printf("[INFO] Adopting existing VRF %s of fabric %s with segment ID %s and VLAN %d, updating %s", vrf.Name, vrf.Fabric, vrf.Id, configMap.Vlan, strings.Join(changes, ", "))
+			durl := fmt.Sprintf("/rest/top-down/fabrics/%s/vrfs/%s", vrf.Fabric, vrf.Name)
+			_, err = dcnmClient.Update(durl, &vrf)
+		}
+	} else {
+		durl := fmt.Sprintf("/rest/top-down/fabrics/%s/vrfs", vrf.Fabric)
+		_, err = dcnmClient.Save(durl, &vrf)
+	}
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(vrf.Name)
 
 	//VRF attachment
 	if deploy, ok := d.GetOk("deploy"); ok && deploy.(bool) == true {
-		if _, ok := d.GetOk("attachments"); ok {
-			attachList := make([]map[string]interface{}, 0, 1)
-			for _, val := range d.Get("attachments").(*schema.Set).List() {
-				attachment := val.(map[string]interface{})
+		deployed := false
+		if existing != nil {
+			states, err := getVRFAttachStates(dcnmClient, vrf.Fabric, vrf.Name)
+			deployed = err == nil && attachmentsMatch(d.Get("attachments").(*schema.Set).List(), states)
+		}
+		if deployed {
+			log.Printf("[INFO] Existing VRF %s is already deployed as configured", vrf.Name)
+		} else if err = attachVRF(dcnmClient, d, &vrf, configMap.Vlan, &reservations); err != nil {
+			return vrfCreateFailure(d, m, err, reservations, existing != nil)
+		}
+	}
+	d.SetId(vrf.Name)
+	log.Println("[DEBUG] End of Create method ", d.Id())
+	return diag.FromErr(resourceDCNMVRFRead(d, m))
+}
 
-				attachMap := make(map[string]interface{})
+// vrfAdoptChanges returns the settings of an existing VRF which differ from
+// the VRF to be adopted, template config keys are prefixed with
+// vrfTemplateConfig.
+func vrfAdoptChanges(vrf *models.VRF, existing *container.Container) ([]string, error) {
+	vrfMap, err := vrf.ToMap()
+	if err != nil {
+		return nil, err
+	}
+	delete(vrfMap, "vrfTemplateConfig")
+	changes := settingsChanges(vrfMap, existing)
 
-				durl := fmt.Sprintf("/rest/control/switches/%s/fabric-name", attachment["serial_number"].(string))
-				cont, err := dcnmClient.GetviaURL(durl)
-				if err != nil {
-					return err
-				}
-				attachmentFabricName := stripQuotes(cont.S("fabricName").String())
+	desired := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(vrf.Config), &desired); err != nil {
+		return nil, err
+	}
+	remote, err := getTemplateConfig(existing, "vrfTemplateConfig")
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range settingsChanges(desired, remote) {
+		changes = append(changes, "vrfTemplateConfig."+key)
+	}
+	return changes, nil
+}
 
-				attachMap["fabric"] = attachmentFabricName
-				attachMap["vrfName"] = vrf.Name
-				attachMap["deployment"] = attachment["attach"].(bool)
-				attachMap["serialNumber"] = attachment["serial_number"].(string)
+// attachVRF attaches the VRF to the switches of the attachments and deploys
+// it. The dot1q IDs reserved for VRF lite are added to reservations.
+func attachVRF(dcnmClient *client.Client, d *schema.ResourceData, vrf *models.VRF, vlan int, reservations *[]resourceReservation) error {
+	if err := saveVRFAttachments(dcnmClient, d, vrf, vlan, reservations); err != nil {
+		return err
+	}
+	return deployVRF(dcnmClient, d, vrf)
+}
 
-				if attachment["vlan_id"].(int) != 0 {
-					attachMap["vlan"] = attachment["vlan_id"].(int)
-				} else {
-					attachMap["vlan"] = configMap.Vlan
-				}
-				if attachment["free_form_config"] != nil {
-					attachMap["freeformConfig"] = attachment["free_form_config"].(string)
-				}
-				if attachment["extension_values"] != nil {
-					attachMap["extensionValues"] = attachment["extension_values"].(string)
-				}
+// saveVRFAttachments attaches or detaches the VRF to/from the switches of the
+// attachments. The dot1q IDs reserved for VRF lite are added to reservations.
+func saveVRFAttachments(dcnmClient *client.Client, d *schema.ResourceData, vrf *models.VRF, vlan int, reservations *[]resourceReservation) error {
+	attachList := make([]map[string]interface{}, 0, 1)
+	for _, val := range d.Get("attachments").(*schema.Set).List() {
+		attachment := val.(map[string]interface{})
 
-				flag := false
-				instance := models.VRFInstance{}
-				if attachment["loopback_id"] != nil {
-					instance.LookbackID = attachment["loopback_id"].(int)
-					flag = true
-				}
-				if attachment["loopback_ipv4"] != nil {
-					instance.LoopbackIpv4 = attachment["loopback_ipv4"].(string)
-					flag = true
-				}
-				if attachment["loopback_ipv6"] != nil {
-					instance.LoopbackIpv6 = attachment["loopback_ipv6"].(string)
-					flag = true
-				}
-				if flag {
-					instStr, err := json.Marshal(instance)
-					if err != nil {
-						return err
-					}
-					attachMap["instanceValues"] = string(instStr)
-				}
+		attachMap := make(map[string]interface{})
+
+		durl := fmt.Sprintf("/rest/control/switches/%s/fabric-name", attachment["serial_number"].(string))
+		cont, err := dcnmClient.GetviaURL(durl)
+		if err != nil {
+			return err
+		}
+		attachmentFabricName := stripQuotes(cont.S("fabricName").String())
+
+		attachMap["fabric"] = attachmentFabricName
+		attachMap["vrfName"] = vrf.Name
+		attachMap["deployment"] = attachment["attach"].(bool)
+		attachMap["serialNumber"] = attachment["serial_number"].(string)
+
+		if attachment["vlan_id"].(int) != 0 {
+			attachMap["vlan"] = attachment["vlan_id"].(int)
+		} else {
+			attachMap["vlan"] = vlan
+		}
+		if attachment["free_form_config"] != nil {
+			attachMap["freeformConfig"] = attachment["free_form_config"].(string)
+		}
+		if attachment["extension_values"] != nil {
+			attachMap["extensionValues"] = attachment["extension_values"].(string)
+		}
+
+		flag := false
+		instance := models.VRFInstance{}
+		if attachment["loopback_id"] != nil {
+			instance.LookbackID = attachment["loopback_id"].(int)
+			flag = true
+		}
+		if attachment["loopback_ipv4"] != nil {
+			instance.LoopbackIpv4 = attachment["loopback_ipv4"].(string)
+			flag = true
+		}
+		if attachment["loopback_ipv6"] != nil {
+			instance.LoopbackIpv6 = attachment["loopback_ipv6"].(string)
+			flag = true
+		}
+		if flag {
+			instStr, err := json.Marshal(instance)
+			if err != nil {
+				return err
+			}
+			attachMap["instanceValues"] = string(instStr)
+		}
 
-				if attachment["vrf_lite"] != nil {
-					vrfLiteList := make([]map[string]interface{}, 0, 1)
-					ifNameNotFoundList := make([]string, 0, 1)
-					for _, val := range attachment["vrf_lite"].(*schema.Set).List() {
-						log.Println("vrf_lite enter")
-						vrfLite := val.(map[string]interface{})
-						vrfLiteMap := make(map[string]interface{})
+		if attachment["vrf_lite"] != nil {
+			vrfLiteList := make([]map[string]interface{}, 0, 1)
+			ifNameNotFoundList := make([]string, 0, 1)
+			for _, val := range attachment["vrf_lite"].(*schema.Set).List() {
+				log.Println("vrf_lite enter")
+				vrfLite := val.(map[string]interface{})
+				vrfLiteMap := make(map[string]interface{})
 
-						durl := fmt.Sprintf("/rest/top-down/fabrics/%s/vrfs/switches?vrf-names=%s&serial-numbers=%s", attachmentFabricName, vrf.Name, attachMap["serialNumber"].(string))
-						cont, err := dcnmClient.GetviaURL(durl)
+				durl := fmt.Sprintf("/rest/top-down/fabrics/%s/vrfs/switches?vrf-names=%s&serial-numbers=%s", attachmentFabricName, vrf.Name, attachMap["serialNumber"].(string))
+				cont, err := dcnmClient.GetviaURL(durl)
+				if err != nil {
+					return err
+				}
+				ifNameFound := false
+				extensionProtValues := cont.Index(0).S("switchDetailsList").Index(0).S("extensionPrototypeValues")
+				for i := 0; i < len(extensionProtValues.Data().([]interface{})); i++ {
+					extensionProtVal := extensionProtValues.Index(i)
+					if ifName := stripQuotes(extensionProtVal.S("interfaceName").String()); ifName == vrfLite["interface_name"] {
+						ifNameFound = true
+						extensionValueString := stripQuotes(extensionProtVal.S("extensionValues").String())
+
+						var extensionValues map[string]interface{}
+						extensionValueString = strings.Replace(extensionValueString, "\\", "", -1)
+						err = json.Unmarshal([]byte(extensionValueString), &extensionValues)
 						if err != nil {
 							return err
 						}
-						ifNameFound := false
-						extensionProtValues := cont.Index(0).S("switchDetailsList").Index(0).S("extensionPrototypeValues")
-						for i := 0; i < len(extensionProtValues.Data().([]interface{})); i++ {
-							extensionProtVal := extensionProtValues.Index(i)
-							if ifName := stripQuotes(extensionProtVal.S("interfaceName").String()); ifName == vrfLite["interface_name"] {
-								ifNameFound = true
-								extensionValueString := stripQuotes(extensionProtVal.S("extensionValues").String())
-
-								var extensionValues map[string]interface{}
-								extensionValueString = strings.Replace(extensionValueString, "\\", "", -1)
-								err = json.Unmarshal([]byte(extensionValueString), &extensionValues)
+						if len(extensionValues) != 0 {
+							vrfLiteMap["PEER_VRF_NAME"] = vrfLite["peer_vrf_name"]
+							vrfLiteMap["IF_NAME"] = vrfLite["interface_name"]
+
+							if vrfLite["dot1q_id"] != "" {
+								vrfLiteMap["DOT1Q_ID"] = vrfLite["dot1q_id"]
+							} else {
+								durl := "/rest/resource-manager/reserve-id"
+								dot1q := models.VRFDot1qID{
+									ScopeType:    "DeviceInterface",
+									UsageType:    "TOP_DOWN_L3_DOT1Q",
+									AllocatedTo:  vrf.Name,
+									SerialNumber: attachMap["serialNumber"].(string),
+									IfName:       ifName,
+								}
+								cont, err := dcnmClient.Save(durl, &dot1q)
 								if err != nil {
 									return err
 								}
-								if len(extensionValues) != 0 {
-									vrfLiteMap["PEER_VRF_NAME"] = vrfLite["peer_vrf_name"]
-									vrfLiteMap["IF_NAME"] = vrfLite["interface_name"]
-
-									if vrfLite["dot1q_id"] != "" {
-										vrfLiteMap["DOT1Q_ID"] = vrfLite["dot1q_id"]
-									} else {
-										durl := "/rest/resource-manager/reserve-id"
-										dot1q := models.VRFDot1qID{
-											ScopeType:    "DeviceInterface",
-											UsageType:    "TOP_DOWN_L3_DOT1Q",
-											AllocatedTo:  vrf.Name,
-											SerialNumber: attachMap["serialNumber"].(string),
-											IfName:       ifName,
-										}
-										cont, err := dcnmClient.Save(durl, &dot1q)
-										if err != nil {
-											return err
-										}
-										vrfLiteMap["DOT1Q_ID"] = stripQuotes(cont.String())
-									}
-
-									if vrfLite["ip_mask"] != "" {
-										vrfLiteMap["IP_MASK"] = vrfLite["ip_mask"].(string)
-									} else if extensionValues["IP_MASK"] != nil {
-										vrfLiteMap["IP_MASK"] = extensionValues["IP_MASK"].(string)
-									}
+								vrfLiteMap["DOT1Q_ID"] = stripQuotes(cont.String())
+								*reservations = append(*reservations, resourceReservation{
+									Pool:   "TOP_DOWN_L3_DOT1Q",
+									Value:  stripQuotes(cont.String()),
+									Entity: dot1q.SerialNumber,
+								})
+							}
 
-									if vrfLite["neighbor_ip"] != "" {
-										vrfLiteMap["NEIGHBOR_IP"] = vrfLite["neighbor_ip"].(string)
-									} else if extensionValues["NEIGHBOR_IP"] != nil {
-										vrfLiteMap["NEIGHBOR_IP"] = extensionValues["NEIGHBOR_IP"].(string)
-									}
+							if vrfLite["ip_mask"] != "" {
+								vrfLiteMap["IP_MASK"] = vrfLite["ip_mask"].(string)
+							} else if extensionValues["IP_MASK"] != nil {
+								vrfLiteMap["IP_MASK"] = extensionValues["IP_MASK"].(string)
+							}
 
-									if vrfLite["neighbor_asn"] != "" {
-										vrfLiteMap["NEIGHBOR_ASN"] = vrfLite["neighbor_asn"].(string)
-									} else if extensionValues["NEIGHBOR_ASN"] != nil {
-										vrfLiteMap["NEIGHBOR_ASN"] = extensionValues["NEIGHBOR_ASN"].(string)
-									}
+							if vrfLite["neighbor_ip"] != "" {
+								vrfLiteMap["NEIGHBOR_IP"] = vrfLite["neighbor_ip"].(string)
+							} else if extensionValues["NEIGHBOR_IP"] != nil {
+								vrfLiteMap["NEIGHBOR_IP"] = extensionValues["NEIGHBOR_IP"].(string)
+							}
 
-									if vrfLite["ipv6_mask"] != "" {
-										vrfLiteMap["IPV6_MASK"] = vrfLite["ipv6_mask"].(string)
-									} else if extensionValues["IPV6_MASK"] != nil {
-										vrfLiteMap["IPV6_MASK"] = extensionValues["IPV6_MASK"].(string)
-									}
+							if vrfLite["neighbor_asn"] != "" {
+								vrfLiteMap["NEIGHBOR_ASN"] = vrfLite["neighbor_asn"].(string)
+							} else if extensionValues["NEIGHBOR_ASN"] != nil {
+								vrfLiteMap["NEIGHBOR_ASN"] = extensionValues["NEIGHBOR_ASN"].(string)
+							}
 
-									if vrfLite["ipv6_neighbor"] != "" {
-										vrfLiteMap["IPV6_NEIGHBOR"] = vrfLite["ipv6_neighbor"].(string)
-									} else if extensionValues["IPV6_NEIGHBOR"] != nil {
-										vrfLiteMap["IPV6_NEIGHBOR"] = extensionValues["IPV6_NEIGHBOR"].(string)
-									}
+							if vrfLite["ipv6_mask"] != "" {
+								vrfLiteMap["IPV6_MASK"] = vrfLite["ipv6_mask"].(string)
+							} else if extensionValues["IPV6_MASK"] != nil {
+								vrfLiteMap["IPV6_MASK"] = extensionValues["IPV6_MASK"].(string)
+							}
 
-									if vrfLite["auto_vrf_lite_flag"] != "" {
-										vrfLiteMap["AUTO_VRF_LITE_FLAG"] = vrfLite["auto_vrf_lite_flag"].(string)
-									} else if extensionValues["AUTO_VRF_LITE_FLAG"] != nil {
-										vrfLiteMap["AUTO_VRF_LITE_FLAG"] = extensionValues["AUTO_VRF_LITE_FLAG"].(string)
-									}
-									vrfLiteMap["VRF_LITE_JYTHON_TEMPLATE"] = extensionValues["VRF_LITE_JYTHON_TEMPLATE"].(string)
+							if vrfLite["ipv6_neighbor"] != "" {
+								vrfLiteMap["IPV6_NEIGHBOR"] = vrfLite["ipv6_neighbor"].(string)
+							} else if extensionValues["IPV6_NEIGHBOR"] != nil {
+								vrfLiteMap["IPV6_NEIGHBOR"] = extensionValues["IPV6_NEIGHBOR"].(string)
+							}
 
-								} else {
-									return fmt.Errorf("No VRF_LITE Data found for switch %s", attachMap["serialNumber"].(string))
-								}
-								contMap := make(map[string]interface{})
-								vrfLiteStr, err := json.Marshal(map[string]interface{}{
-									"VRF_LITE_CONN": vrfLiteList,
-								})
-								if err != nil {
-									return err
-								}
-								contMap["VRF_LITE_CONN"] = string(vrfLiteStr)
-								vrfLiteStr, err = json.Marshal(contMap)
-								if err != nil {
-									return err
-								}
-								attachMap["extensionValues"] = string(vrfLiteStr)
+							if vrfLite["auto_vrf_lite_flag"] != "" {
+								vrfLiteMap["AUTO_VRF_LITE_FLAG"] = vrfLite["auto_vrf_lite_flag"].(string)
+							} else if extensionValues["AUTO_VRF_LITE_FLAG"] != nil {
+								vrfLiteMap["AUTO_VRF_LITE_FLAG"] = extensionValues["AUTO_VRF_LITE_FLAG"].(string)
 							}
+							vrfLiteMap["VRF_LITE_JYTHON_TEMPLATE"] = extensionValues["VRF_LITE_JYTHON_TEMPLATE"].(string)
+
+						} else {
+							return fmt.Errorf("No VRF_LITE Data found for switch %s", attachMap["serialNumber"].(string))
 						}
-						if !ifNameFound {
-							ifNameNotFoundList = append(ifNameNotFoundList, vrfLite["interface_name"].(string))
+						contMap := make(map[string]interface{})
+						vrfLiteStr, err := json.Marshal(map[string]interface{}{
+							"VRF_LITE_CONN": vrfLiteList,
+						})
+						if err != nil {
+							return err
 						}
-
-						vrfLiteList = append(vrfLiteList, vrfLiteMap)
+						contMap["VRF_LITE_CONN"] = string(vrfLiteStr)
+						vrfLiteStr, err = json.Marshal(contMap)
+						if err != nil {
+							return err
+						}
+						attachMap["extensionValues"] = string(vrfLiteStr)
 					}
+				}
+				if !ifNameFound {
+					ifNameNotFoundList = append(ifNameNotFoundList, vrfLite["interface_name"].(string))
+				}
 
-					if len(ifNameNotFoundList) > 0 {
-						return fmt.Errorf("VRF LITE Config not found for attachment:%s", ifNameNotFoundList)
-					}
+				vrfLiteList = append(vrfLiteList, vrfLiteMap)
+			}
 
-					contMap := make(map[string]interface{})
-					vrfLiteStr, err := json.Marshal(map[string]interface{}{
-						"VRF_LITE_CONN": vrfLiteList,
-					})
-					if err != nil {
-						return err
-					}
-					contMap["VRF_LITE_CONN"] = string(vrfLiteStr)
-					vrfLiteStr, err = json.Marshal(contMap)
-					if err != nil {
-						return err
-					}
-					attachMap["extensionValues"] = string(vrfLiteStr)
-				} else {
-					attachMap["extensionValues"] = ""
-				}
-				attachList = append(attachList, attachMap)
+			if len(ifNameNotFoundList) > 0 {
+				return fmt.Errorf("VRF LITE Config not found for attachment:%s", ifNameNotFoundList)
 			}
 
-			vrfAttach := models.NewVRFAttachment(vrf.Name, attachList)
-			durl := fmt.Sprintf("/rest/top-down/fabrics/%s/vrfs/attachments", vrf.Fabric)
-			cont, err := dcnmClient.SaveForAttachment(durl, vrfAttach)
+			contMap := make(map[string]interface{})
+			vrfLiteStr, err := json.Marshal(map[string]interface{}{
+				"VRF_LITE_CONN": vrfLiteList,
+			})
 			if err != nil {
 				return err
 			}
-
-			// VRF Deployment
-			for _, v := range cont.Data().(map[string]interface{}) {
-				if v != "SUCCESS" && v != "SUCCESS Peer attach Response -  SUCCESS" {
-					return fmt.Errorf("VRF record is created but not deployed yet. Error while attachment : %s", v)
-				}
-			}
-			vrfD := models.VRFDeploy{}
-			vrfD.Name = vrf.Name
-			durl = fmt.Sprintf("/rest/top-down/fabrics/%s/vrfs/deployments", vrf.Fabric)
-			_, err = dcnmClient.Save(durl, &vrfD)
+			contMap["VRF_LITE_CONN"] = string(vrfLiteStr)
+			vrfLiteStr, err = json.Marshal(contMap)
 			if err != nil {
-				d.Set("deploy", false)
+				return err
 			}
+			attachMap["extensionValues"] = string(vrfLiteStr)
+		} else {
+			attachMap["extensionValues"] = ""
+		}
+		attachList = append(attachList, attachMap)
+	}
 
-			deployFlag := false
-			deployTimeout := d.Get("deploy_timeout").(int)
-			for j := 0; j < (deployTimeout / 5); j++ {
-				deployStatus, err := getVRFDeploymentStatus(dcnmClient, vrf.Fabric, vrf.Name)
-				if err != nil {
-					return err
-				}
-				deployFlag = deployStatus == "DEPLOYED"
-				if !deployFlag {
-					time.Sleep(5 * time.Second)
-				} else {
-					deployFlag = true
-					break
-				}
-			}
-			if !deployFlag {
-				return fmt.Errorf("VRF record is created but not deployed yet. deployment timeout occured")
-			}
+	vrfAttach := models.NewVRFAttachment(vrf.Name, attachList)
+	durl := fmt.Sprintf("/rest/top-down/fabrics/%s/vrfs/attachments", vrf.Fabric)
+	cont, err := dcnmClient.SaveForAttachment(durl, vrfAttach)
+	if err != nil {
+		return err
+	}
 
-		} else {
-			d.Set("deploy", false)
-			d.Set("attachments", make([]interface{}, 0, 1))
-			return fmt.Errorf("VRF record is created but not deployed yet. Either make deploy=false or provide attachments")
+	for _, v := range cont.Data().(map[string]interface{}) {
+		if v != "SUCCESS" && v != "SUCCESS Peer attach Response -  SUCCESS" {
+			return fmt.Errorf("Error while attachment : %s", v)
 		}
 	}
-	d.SetId(vrf.Name)
-	log.Println("[DEBUG] End of Create method ", d.Id())
-	return resourceDCNMVRFRead(d, m)
+	return nil
+}
+
+// deployVRF deploys the VRF to the switches it is attached to and waits for
+// the deployment.
+func deployVRF(dcnmClient *client.Client, d *schema.ResourceData, vrf *models.VRF) error {
+	states, err := getVRFAttachStates(dcnmClient, vrf.Fabric, vrf.Name)
+	if err != nil {
+		return err
+	}
+	stale := staleFailures(states)
+
+	vrfD := models.VRFDeploy{}
+	vrfD.Name = vrf.Name
+	durl := fmt.Sprintf("/rest/top-down/fabrics/%s/vrfs/deployments", vrf.Fabric)
+	_, err = dcnmClient.Save(durl, &vrfD)
+	if err != nil {
+		return err
+	}
+
+	return waitForVRFDeployment(dcnmClient, vrf.Fabric, vrf.Name, stale, d.Get("deploy_timeout").(int))
+}
+
+// vrfCreateFailure handles a failed attachment or deployment of a newly
+// created VRF as set by on_create_failure. A rollback detaches and deletes the
+// VRF again and releases the IDs reserved for it. Otherwise the VRF is kept
+// with a warning, attached_switches records the switches it actually got
+// attached to.
+func vrfCreateFailure(d *schema.ResourceData, m interface{}, createErr error, reservations []resourceReservation, adopted bool) diag.Diagnostics {
+	dcnmClient := m.(*client.Client)
+	vrfName := d.Id()
+	fabricName := d.Get("fabric_name").(string)
+
+	// an adopted VRF existed before the apply, so it is never rolled back
+	if !adopted && d.Get("on_create_failure").(string) == "rollback" {
+		log.Printf("[DEBUG] Rolling back VRF %s after failure: %s", vrfName, createErr)
+		if err := resourceDCNMVRFDelete(d, m); err != nil {
+			return diag.Errorf("VRF %s failed to deploy: %s. Rollback failed: %s", vrfName, createErr, err)
+		}
+		if err := releaseReservations(dcnmClient, fabricName, reservations); err != nil {
+			return diag.Errorf("VRF %s failed to deploy and is deleted: %s. Releasing its IDs failed: %s", vrfName, createErr, err)
+		}
+		return diag.Errorf("VRF %s failed to deploy and is rolled back: %s", vrfName, createErr)
+	}
+
+	action := "created"
+	if adopted {
+		action = "adopted"
+	}
+	if err := resourceDCNMVRFRead(d, m); err != nil {
+		return diag.Errorf("VRF %s is %s but failed to deploy: %s. Reading its state failed: %s", vrfName, action, createErr, err)
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("VRF %s is %s but failed to deploy", vrfName, action),
+			Detail:   fmt.Sprintf("%s. The switches the VRF is attached to are listed in attached_switches, the next apply retries the deployment.", createErr),
+		},
+	}
 }
 
 func resourceDCNMVRFUpdate(d *schema.ResourceData, m interface{}) error {
@@ -891,12 +1033,6 @@ func resourceDCNMVRFUpdate(d *schema.ResourceData, m interface{}) error {
 	if ibgp, ok := d.GetOk("max_ibgp_path"); ok {
 		configMap.IBGP = ibgp.(int)
 	}
-	if trm, ok := d.GetOk("trm_enable"); ok {
-		configMap.TRM = trm.(string)
-	}
-	if rpExtr, ok := d.GetOk("rp_external_flag"); ok {
-		configMap.RPexternal = rpExtr.(string)
-	}
 	if rpAddr, ok := d.GetOk("rp_address"); ok {
 		configMap.RPaddress = rpAddr.(string)
 	}
@@ -909,21 +1045,7 @@ func resourceDCNMVRFUpdate(d *schema.ResourceData, m interface{}) error {
 	if mcastGrp, ok := d.GetOk("mutlicast_group"); ok {
 		configMap.Mcastgroup = mcastGrp.(string)
 	}
-	if ipv6, ok := d.GetOk("ipv6_link_local_flag"); ok {
-		configMap.IPv6Link = ipv6.(string)
-	}
-	if trmbgw, ok := d.GetOk("trm_bgw_msite_flag"); ok {
-		configMap.TRMBGW = trmbgw.(string)
-	}
-	if hostR, ok := d.GetOk("advertise_host_route"); ok {
-		configMap.AdhostRoute = hostR.(string)
-	}
-	if defaultR, ok := d.GetOk("advertise_default_route"); ok {
-		configMap.AdDefaultRoute = defaultR.(string)
-	}
-	if staticR, ok := d.GetOk("static_default_route"); ok {
-		configMap.StaticRoute = staticR.(string)
-	}
+	setVRFFlags(d, &configMap)
 	configMap.SegmentID = vrf.Id
 	configMap.VrfName = vrf.Name
 
@@ -931,7 +1053,10 @@ func resourceDCNMVRFUpdate(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	vrf.Config = string(confStr)
+	vrf.Config, err = mergeTemplateProps(confStr, d.Get("template_props").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
 
 	dn := d.Id()
 	durl := fmt.Sprintf("/rest/top-down/fabrics/%s/vrfs/%s", vrf.Fabric, dn)
@@ -943,237 +1068,36 @@ func resourceDCNMVRFUpdate(d *schema.ResourceData, m interface{}) error {
 
 	//VRF Attachment
 	if d.HasChange("deploy") && d.Get("deploy").(bool) == false {
-		return fmt.Errorf("Deployed VRF can not be undeployed")
+		oldAttachments, _ := d.GetChange("attachments")
+		err := detachVRFAttachments(dcnmClient, d, vrf.Fabric, vrf.Name, oldAttachments.(*schema.Set).List(), true)
+		if err != nil {
+			return err
+		}
+	} else if d.HasChange("attachments") && !d.HasChange("deploy") && d.Get("deploy").(bool) {
+		oldAttachments, newAttachments := d.GetChange("attachments")
+		removed := removedAttachments(oldAttachments.(*schema.Set), newAttachments.(*schema.Set))
+		if err := detachVRFAttachments(dcnmClient, d, vrf.Fabric, vrf.Name, removed, false); err != nil {
+			return err
+		}
 	}
 
 	if deploy, ok := d.GetOk("deploy"); ok && deploy.(bool) == true {
 		if _, ok := d.GetOk("attachments"); ok {
-			attachList := make([]map[string]interface{}, 0, 1)
-			for _, val := range d.Get("attachments").(*schema.Set).List() {
-				attachment := val.(map[string]interface{})
-
-				attachMap := make(map[string]interface{})
-
-				durl := fmt.Sprintf("/rest/control/switches/%s/fabric-name", attachment["serial_number"].(string))
-				cont, err := dcnmClient.GetviaURL(durl)
-				if err != nil {
-					return err
-				}
-				attachmentFabricName := stripQuotes(cont.S("fabricName").String())
-
-				attachMap["fabric"] = attachmentFabricName
-				attachMap["vrfName"] = vrf.Name
-				attachMap["deployment"] = attachment["attach"].(bool)
-				attachMap["serialNumber"] = attachment["serial_number"].(string)
-
-				if attachment["vlan_id"].(int) != 0 {
-					attachMap["vlan"] = attachment["vlan_id"].(int)
-				} else {
-					attachMap["vlan"] = configMap.Vlan
-				}
-				if attachment["free_form_config"] != nil {
-					attachMap["freeformConfig"] = attachment["free_form_config"].(string)
-				}
-				if attachment["extension_values"] != nil {
-					attachMap["extensionValues"] = attachment["extension_values"].(string)
-				}
-
-				flag := false
-				instance := models.VRFInstance{}
-				if attachment["loopback_id"] != nil {
-					instance.LookbackID = attachment["loopback_id"].(int)
-					flag = true
-				}
-				if attachment["loopback_ipv4"] != nil {
-					instance.LoopbackIpv4 = attachment["loopback_ipv4"].(string)
-					flag = true
-				}
-				if attachment["loopback_ipv6"] != nil {
-					instance.LoopbackIpv6 = attachment["loopback_ipv6"].(string)
-					flag = true
-				}
-				if flag {
-					instStr, err := json.Marshal(instance)
-					if err != nil {
-						return err
-					}
-					attachMap["instanceValues"] = string(instStr)
-				}
-
-				if attachment["vrf_lite"] != nil {
-					vrfLiteList := make([]map[string]interface{}, 0, 1)
-					ifNameNotFoundList := make([]string, 0, 1)
-					for _, val := range attachment["vrf_lite"].(*schema.Set).List() {
-						log.Println("vrf_lite enter")
-						vrfLite := val.(map[string]interface{})
-						vrfLiteMap := make(map[string]interface{})
-
-						durl := fmt.Sprintf("/rest/top-down/fabrics/%s/vrfs/switches?vrf-names=%s&serial-numbers=%s", attachmentFabricName, vrf.Name, attachMap["serialNumber"].(string))
-						cont, err := dcnmClient.GetviaURL(durl)
-						if err != nil {
-							return err
-						}
-						ifNameFound := false
-						extensionProtValues := cont.Index(0).S("switchDetailsList").Index(0).S("extensionPrototypeValues")
-						for i := 0; i < len(extensionProtValues.Data().([]interface{})); i++ {
-							extensionProtVal := extensionProtValues.Index(i)
-							if ifName := stripQuotes(extensionProtVal.S("interfaceName").String()); ifName == vrfLite["interface_name"] {
-								ifNameFound = true
-								extensionValueString := stripQuotes(extensionProtVal.S("extensionValues").String())
-
-								var extensionValues map[string]interface{}
-								extensionValueString = strings.Replace(extensionValueString, "\\", "", -1)
-								err = json.Unmarshal([]byte(extensionValueString), &extensionValues)
-								if err != nil {
-									return err
-								}
-								if len(extensionValues) != 0 {
-									vrfLiteMap["PEER_VRF_NAME"] = vrfLite["peer_vrf_name"]
-									vrfLiteMap["IF_NAME"] = vrfLite["interface_name"]
-
-									if vrfLite["dot1q_id"] != "" {
-										vrfLiteMap["DOT1Q_ID"] = vrfLite["dot1q_id"]
-									} else {
-										durl := "/rest/resource-manager/reserve-id"
-										dot1q := models.VRFDot1qID{
-											ScopeType:    "DeviceInterface",
-											UsageType:    "TOP_DOWN_L3_DOT1Q",
-											AllocatedTo:  vrf.Name,
-											SerialNumber: attachMap["serialNumber"].(string),
-											IfName:       ifName,
-										}
-										cont, err := dcnmClient.Save(durl, &dot1q)
-										if err != nil {
-											return err
-										}
-										vrfLiteMap["DOT1Q_ID"] = stripQuotes(cont.String())
-									}
-
-									if vrfLite["ip_mask"] != "" {
-										vrfLiteMap["IP_MASK"] = vrfLite["ip_mask"].(string)
-									} else if extensionValues["IP_MASK"] != nil {
-										vrfLiteMap["IP_MASK"] = extensionValues["IP_MASK"].(string)
-									}
-
-									if vrfLite["neighbor_ip"] != "" {
-										vrfLiteMap["NEIGHBOR_IP"] = vrfLite["neighbor_ip"].(string)
-									} else if extensionValues["NEIGHBOR_IP"] != nil {
-										vrfLiteMap["NEIGHBOR_IP"] = extensionValues["NEIGHBOR_IP"].(string)
-									}
-
-									if vrfLite["neighbor_ip"] != "" {
-										vrfLiteMap["NEIGHBOR_ASN"] = vrfLite["neighbor_asn"].(string)
-									} else if extensionValues["NEIGHBOR_ASN"] != nil {
-										vrfLiteMap["NEIGHBOR_ASN"] = extensionValues["NEIGHBOR_ASN"].(string)
-									}
-
-									if vrfLite["ipv6_mask"] != "" {
-										vrfLiteMap["IPV6_MASK"] = vrfLite["ipv6_mask"].(string)
-									} else if extensionValues["IPV6_MASK"] != nil {
-										vrfLiteMap["IPV6_MASK"] = extensionValues["IPV6_MASK"].(string)
-									}
-
-									if vrfLite["ipv6_neighbor"] != "" {
-										vrfLiteMap["IPV6_NEIGHBOR"] = vrfLite["ipv6_neighbor"].(string)
-									} else if extensionValues["IPV6_NEIGHBOR"] != nil {
-										vrfLiteMap["IPV6_NEIGHBOR"] = extensionValues["IPV6_NEIGHBOR"].(string)
-									}
-
-									if vrfLite["auto_vrf_lite_flag"] != "" {
-										vrfLiteMap["AUTO_VRF_LITE_FLAG"] = vrfLite["auto_vrf_lite_flag"].(string)
-									} else if extensionValues["AUTO_VRF_LITE_FLAG"] != nil {
-										vrfLiteMap["AUTO_VRF_LITE_FLAG"] = extensionValues["AUTO_VRF_LITE_FLAG"].(string)
-									}
-									vrfLiteMap["VRF_LITE_JYTHON_TEMPLATE"] = extensionValues["VRF_LITE_JYTHON_TEMPLATE"].(string)
-
-								} else {
-									return fmt.Errorf("No VRF_LITE Data found for switch %s", attachMap["serialNumber"].(string))
-								}
-								contMap := make(map[string]interface{})
-								vrfLiteStr, err := json.Marshal(map[string]interface{}{
-									"VRF_LITE_CONN": vrfLiteList,
-								})
-								if err != nil {
-									return err
-								}
-								contMap["VRF_LITE_CONN"] = string(vrfLiteStr)
-								vrfLiteStr, err = json.Marshal(contMap)
-								if err != nil {
-									return err
-								}
-								attachMap["extensionValues"] = string(vrfLiteStr)
-							}
-						}
-						if !ifNameFound {
-							ifNameNotFoundList = append(ifNameNotFoundList, vrfLite["interface_name"].(string))
-						}
-
-						vrfLiteList = append(vrfLiteList, vrfLiteMap)
-					}
-
-					if len(ifNameNotFoundList) > 0 {
-						return fmt.Errorf("VRF LITE Config not found for attachment:%s", ifNameNotFoundList)
-					}
-
-					contMap := make(map[string]interface{})
-					vrfLiteStr, err := json.Marshal(map[string]interface{}{
-						"VRF_LITE_CONN": vrfLiteList,
-					})
-					if err != nil {
-						return err
-					}
-					contMap["VRF_LITE_CONN"] = string(vrfLiteStr)
-					vrfLiteStr, err = json.Marshal(contMap)
-					if err != nil {
-						return err
+			reservations := make([]resourceReservation, 0)
+			if err := saveVRFAttachments(dcnmClient, d, &vrf, configMap.Vlan, &reservations); err != nil {
+				d.Set("deploy", false)
+				// dot1q IDs of switches which did not get attached are unused
+				if states, stateErr := getVRFAttachStates(dcnmClient, vrf.Fabric, vrf.Name); stateErr == nil {
+					if relErr := releaseReservations(dcnmClient, vrf.Fabric, unattachedReservations(reservations, states)); relErr != nil {
+						log.Printf("[WARN] Releasing the dot1q IDs of VRF %s failed: %s", vrf.Name, relErr)
 					}
-					attachMap["extensionValues"] = string(vrfLiteStr)
-				} else {
-					attachMap["extensionValues"] = ""
 				}
-
-				attachList = append(attachList, attachMap)
+				return fmt.Errorf("VRF record is updated but not deployed yet. %s", err)
 			}
 
-			vrfAttach := models.NewVRFAttachment(vrf.Name, attachList)
-			durl := fmt.Sprintf("/rest/top-down/fabrics/%s/vrfs/attachments", vrf.Fabric)
-			cont, err := dcnmClient.SaveForAttachment(durl, vrfAttach)
-			if err != nil {
-				return err
-			}
-
-			// VRF Deployment
-			for _, v := range cont.Data().(map[string]interface{}) {
-				if v != "SUCCESS" && v != "SUCCESS Peer attach Response -  SUCCESS" {
-					return fmt.Errorf("VRF record is created but not deployed yet. Error while attachment : %s", v)
-				}
-			}
-			vrfD := models.VRFDeploy{}
-			vrfD.Name = vrf.Name
-			durl = fmt.Sprintf("/rest/top-down/fabrics/%s/vrfs/deployments", vrf.Fabric)
-			_, err = dcnmClient.Save(durl, &vrfD)
-			if err != nil {
-				d.Set("deploy", false)
-			}
-
-			deployFlag := false
-			deployTimeout := d.Get("deploy_timeout").(int)
-			for j := 0; j < (deployTimeout / 5); j++ {
-				deployStatus, err := getVRFDeploymentStatus(dcnmClient, vrf.Fabric, vrf.Name)
-				if err != nil {
-					return err
-				}
-				deployFlag = deployStatus == "DEPLOYED"
-				if !deployFlag {
-					time.Sleep(5 * time.Second)
-				} else {
-					deployFlag = true
-					break
-				}
-			}
-			if !deployFlag {
+			if err := deployVRF(dcnmClient, d, &vrf); err != nil {
 				d.Set("deploy", false)
-				return fmt.Errorf("VRF record is updated and deployment is initialised, but deployment timeout occured before completion of the deployment process")
+				return fmt.Errorf("VRF record is updated and deployment is initialised, but %s", err)
 			}
 
 		} else {
@@ -1202,6 +1126,12 @@ func resourceDCNMVRFRead(d *schema.ResourceData, m interface{}) error {
 
 	setVRFAttributes(d, cont)
 
+	if props, ok := d.GetOk("template_props"); ok {
+		if configCont, err := getTemplateConfig(cont, "vrfTemplateConfig"); err == nil {
+			d.Set("template_props", readTemplateProps(configCont, props.(map[string]interface{})))
+		}
+	}
+
 	flag, err := checkvrfDeploy(dcnmClient, fabricName, dn)
 	if err != nil {
 		d.Set("deploy", false)
@@ -1209,6 +1139,12 @@ func resourceDCNMVRFRead(d *schema.ResourceData, m interface{}) error {
 	}
 	d.Set("deploy", flag)
 
+	states, err := getVRFAttachStates(dcnmClient, fabricName, dn)
+	if err != nil {
+		return err
+	}
+	d.Set("attached_switches", attachedSwitches(states))
+
 	if attaches, ok := d.GetOk("attachments"); ok {
 		attachGet := make([]interface{}, 0, 1)
 
@@ -1216,8 +1152,11 @@ func resourceDCNMVRFRead(d *schema.ResourceData, m interface{}) error {
 			attachMap := val.(map[string]interface{})
 			serialNum := attachMap["serial_number"].(string)
 
+			setAttachmentState(attachMap, states[serialNum])
+
+			// an undeployed VRF keeps the configured attachments
 			attachStatus, vlan, err := getSwitchAttachStatus(dcnmClient, fabricName, dn, serialNum)
-			if err == nil {
+			if err == nil && flag {
 				attachMap["attach"] = attachStatus
 				if attachMap["vlan_id"].(int) != 0 {
 					attachMap["vlan_id"] = vlan
@@ -1329,69 +1268,10 @@ func resourceDCNMVRFDelete(d *schema.ResourceData, m interface{}) error {
 	fabricName := d.Get("fabric_name").(string)
 
 	if deploy, ok := d.GetOk("deploy"); ok && deploy.(bool) == true {
-		if _, ok := d.GetOk("attachments"); ok {
-			attachList := make([]map[string]interface{}, 0, 1)
-			for _, val := range d.Get("attachments").(*schema.Set).List() {
-				attachment := val.(map[string]interface{})
-
-				attachMap := make(map[string]interface{})
-				durl := fmt.Sprintf("/rest/control/switches/%s/fabric-name", attachment["serial_number"].(string))
-				cont, err := dcnmClient.GetviaURL(durl)
-				if err != nil {
-					return err
-				}
-				attachmentFabricName := stripQuotes(cont.S("fabricName").String())
-				attachMap["fabric"] = attachmentFabricName
-				attachMap["vrfName"] = dn
-				attachMap["deployment"] = false
-				attachMap["serialNumber"] = attachment["serial_number"].(string)
-				if attachment["vlan_id"].(int) == 0 {
-					attachMap["vlan"] = d.Get("vlan_id").(int)
-				} else {
-					attachMap["vlan"] = attachment["vlan_id"].(int)
-				}
-
-				attachList = append(attachList, attachMap)
-			}
-
-			vrfAttach := models.NewVRFAttachment(dn, attachList)
-			durl := fmt.Sprintf("/rest/top-down/fabrics/%s/vrfs/attachments", fabricName)
-			cont, err := dcnmClient.SaveForAttachment(durl, vrfAttach)
-			if err != nil {
-				return err
-			}
-
-			// VRF Deployment
-			for _, v := range cont.Data().(map[string]interface{}) {
-				if v != "SUCCESS" && v != "SUCCESS Peer attach Response -  SUCCESS" {
-					return fmt.Errorf("failure at the time of detachment : %s", v)
-				}
-			}
-			vrfD := models.VRFDeploy{}
-			vrfD.Name = dn
-			durl = fmt.Sprintf("/rest/top-down/fabrics/%s/vrfs/deployments", fabricName)
-			_, err = dcnmClient.Save(durl, &vrfD)
+		if attachments, ok := d.GetOk("attachments"); ok {
+			err := detachVRFAttachments(dcnmClient, d, fabricName, dn, attachments.(*schema.Set).List(), true)
 			if err != nil {
-				d.Set("deploy", false)
-			}
-
-			deployFlag := false
-			deployTimeout := d.Get("deploy_timeout").(int)
-			for j := 0; j < int(deployTimeout/5); j++ {
-				deployStatus, err := getVRFDeploymentStatus(dcnmClient, fabricName, dn)
-				if err != nil {
-					return err
-				}
-				deployFlag = deployStatus == "NA"
-				if !deployFlag {
-					time.Sleep(5 * time.Second)
-				} else {
-					deployFlag = true
-					break
-				}
-			}
-			if !deployFlag {
-				return fmt.Errorf("VRF record can not be deleted. deployment timeout occured")
+				return fmt.Errorf("VRF record can not be deleted. %s", err)
 			}
 		}
 	}
@@ -1407,24 +1287,141 @@ func resourceDCNMVRFDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func checkvrfDeploy(client *client.Client, fabric, vrf string) (bool, error) {
-	durl := fmt.Sprintf("/rest/top-down/fabrics/%s/vrfs/attachments?vrf-names=%s", fabric, vrf)
-	cont, err := client.GetviaURL(durl)
+// vrfSwitchDeployment returns the payload deploying a VRF on the given
+// switches only, keyed by serial number.
+func vrfSwitchDeployment(vrfName string, serials []string) map[string]interface{} {
+	payload := make(map[string]interface{}, len(serials))
+	for _, serial := range serials {
+		payload[serial] = vrfName
+	}
+	return payload
+}
+
+// detachVRFAttachments detaches the VRF from the switches of attachments,
+// deploys the removal and waits for it. With all set the VRF itself has to
+// reach NA, otherwise only the detached switches.
+func detachVRFAttachments(dcnmClient *client.Client, d *schema.ResourceData, fabricName, vrfName string, attachments []interface{}, all bool) error {
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	attachList := make([]map[string]interface{}, 0, len(attachments))
+	serials := make([]string, 0, len(attachments))
+	for _, val := range attachments {
+		attachment := val.(map[string]interface{})
+
+		attachMap := make(map[string]interface{})
+		durl := fmt.Sprintf("/rest/control/switches/%s/fabric-name", attachment["serial_number"].(string))
+		cont, err := dcnmClient.GetviaURL(durl)
+		if err != nil {
+			return err
+		}
+		attachmentFabricName := stripQuotes(cont.S("fabricName").String())
+		attachMap["fabric"] = attachmentFabricName
+		attachMap["vrfName"] = vrfName
+		attachMap["deployment"] = false
+		attachMap["serialNumber"] = attachment["serial_number"].(string)
+		if attachment["vlan_id"].(int) == 0 {
+			attachMap["vlan"] = d.Get("vlan_id").(int)
+		} else {
+			attachMap["vlan"] = attachment["vlan_id"].(int)
+		}
+
+		attachList = append(attachList, attachMap)
+		serials = append(serials, attachment["serial_number"].(string))
+	}
+
+	vrfAttach := models.NewVRFAttachment(vrfName, attachList)
+	durl := fmt.Sprintf("/rest/top-down/fabrics/%s/vrfs/attachments", fabricName)
+	cont, err := dcnmClient.SaveForAttachment(durl, vrfAttach)
 	if err != nil {
-		return false, err
+		return err
 	}
 
-	attachList := cont.Index(0).S("lanAttachList")
+	for _, v := range cont.Data().(map[string]interface{}) {
+		if v != "SUCCESS" && v != "SUCCESS Peer attach Response -  SUCCESS" {
+			return fmt.Errorf("failure at the time of detachment : %s", v)
+		}
+	}
 
-	flag := false
-	for i := 0; i < len(attachList.Data().([]interface{})); i++ {
-		if stripQuotes(attachList.Index(i).S("lanAttachState").String()) == "DEPLOYED" {
-			flag = true
-			break
+	states, err := getVRFAttachStates(dcnmClient, fabricName, vrfName)
+	if err != nil {
+		return err
+	}
+	stale := staleFailures(states)
+
+	if all {
+		vrfD := models.VRFDeploy{}
+		vrfD.Name = vrfName
+		durl = fmt.Sprintf("/rest/top-down/fabrics/%s/vrfs/deployments", fabricName)
+		_, err = dcnmClient.Save(durl, &vrfD)
+	} else {
+		// deploy the removal on the detached switches only, pending changes of
+		// the VRF on other switches are left to their own deployment
+		_, err = makeAndDoRequest(dcnmClient, "POST", "/rest/top-down/v2/vrfs/deploy", vrfSwitchDeployment(vrfName, serials), false)
+	}
+	if err != nil {
+		return err
+	}
+
+	deployTimeout := d.Get("deploy_timeout").(int)
+	for j := 0; j < int(deployTimeout/5); j++ {
+		states, err := getVRFAttachStates(dcnmClient, fabricName, vrfName)
+		if err != nil {
+			return err
+		}
+		if err := attachmentFailure(states, stale); err != nil {
+			return err
+		}
+
+		detached := true
+		if all {
+			deployStatus, err := getVRFDeploymentStatus(dcnmClient, fabricName, vrfName)
+			if err != nil {
+				return err
+			}
+			detached = deployStatus == "NA"
+		} else {
+			for _, serial := range serials {
+				if state, ok := states[serial]; ok && state.Status != "NA" {
+					detached = false
+					break
+				}
+			}
+		}
+		if detached {
+			return nil
+		}
+		time.Sleep(5 * time.Second)
+	}
+	return fmt.Errorf("deployment timeout occured before VRF %s was detached from %s", vrfName, strings.Join(serials, ","))
+}
+
+// removedAttachments returns the attachments of old whose switch is not part
+// of new anymore.
+func removedAttachments(old, new *schema.Set) []interface{} {
+	serials := make(map[string]bool)
+	for _, val := range new.List() {
+		serials[val.(map[string]interface{})["serial_number"].(string)] = true
+	}
+
+	removed := make([]interface{}, 0)
+	for _, val := range old.List() {
+		if !serials[val.(map[string]interface{})["serial_number"].(string)] {
+			removed = append(removed, val)
 		}
 	}
+	return removed
+}
 
-	return flag, nil
+// checkvrfDeploy returns whether the VRF is deployed to all the switches it
+// is attached to.
+func checkvrfDeploy(client *client.Client, fabric, vrf string) (bool, error) {
+	states, err := getVRFAttachStates(client, fabric, vrf)
+	if err != nil {
+		return false, err
+	}
+	return attachmentsDeployed(states), nil
 }
 
 func getSwitchAttachStatus(client *client.Client, fabric, vrf, switchNum string) (bool, int, error) {
@@ -1471,3 +1468,37 @@ func getVRFDeploymentStatus(client *client.Client, fabricName, vrfName string) (
 
 	return status, nil
 }
+
+func getVRFAttachStates(client *client.Client, fabric, vrf string) (map[string]attachmentState, error) {
+	durl := fmt.Sprintf("/rest/top-down/fabrics/%s/vrfs/attachments?vrf-names=%s", fabric, vrf)
+	cont, err := client.GetviaURL(durl)
+	if err != nil {
+		return nil, err
+	}
+	return parseAttachmentStates(cont.Index(0).S("lanAttachList").Children()), nil
+}
+
+// waitForVRFDeployment waits for the VRF to be deployed. It fails as soon as
+// the deployment to any of the switches has failed, FAILED states of stale
+// taken before the deploy request are only reported once they change.
+func waitForVRFDeployment(client *client.Client, fabricName, vrfName string, stale map[string]bool, deployTimeout int) error {
+	for j := 0; j < (deployTimeout / 5); j++ {
+		states, err := getVRFAttachStates(client, fabricName, vrfName)
+		if err != nil {
+			return err
+		}
+		if err := attachmentFailure(states, stale); err != nil {
+			return err
+		}
+
+		deployStatus, err := getVRFDeploymentStatus(client, fabricName, vrfName)
+		if err != nil {
+			return err
+		}
+		if deployStatus == "DEPLOYED" {
+			return nil
+		}
+		time.Sleep(5 * time.Second)
+	}
+	return fmt.Errorf("deployment timeout occured")
+}
@@ -0,0 +1,369 @@
+package dcnm
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourceDCNMVRFV0 is the schema of version 0 of dcnm_vrf, with the flags as
+// "true"/"false" strings.
+func resourceDCNMVRFV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"fabric_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"segment_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"template": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Default_VRF_Universal",
+			},
+
+			"vlan_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"mtu": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  9216,
+			},
+
+			"vlan_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"intf_description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"tag": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "12345",
+			},
+
+			"max_bgp_path": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  1,
+			},
+
+			"max_ibgp_path": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  2,
+			},
+
+			"trm_enable": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"true",
+					"false",
+				}, false),
+			},
+
+			"rp_external_flag": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"true",
+					"false",
+				}, false),
+			},
+
+			"rp_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"loopback_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"mutlicast_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"mutlicast_group": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"ipv6_link_local_flag": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "true",
+				ValidateFunc: validation.StringInSlice([]string{
+					"true",
+					"false",
+				}, false),
+			},
+
+			"trm_bgw_msite_flag": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"true",
+					"false",
+				}, false),
+			},
+
+			"advertise_host_route": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"true",
+					"false",
+				}, false),
+			},
+
+			"advertise_default_route": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "true",
+				ValidateFunc: validation.StringInSlice([]string{
+					"true",
+					"false",
+				}, false),
+			},
+
+			"static_default_route": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "true",
+				ValidateFunc: validation.StringInSlice([]string{
+					"true",
+					"false",
+				}, false),
+			},
+
+			"extension_template": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Default_VRF_Extension_Universal",
+			},
+
+			"service_template": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"source": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"template_props": &schema.Schema{
+				Type:         schema.TypeMap,
+				Optional:     true,
+				ValidateFunc: validateTemplateProps("vrfName", "vrfSegmentId"),
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"deploy": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"deploy_timeout": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  300,
+			},
+
+			"attachments": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"serial_number": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"vlan_id": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+						},
+
+						"attach": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+
+						"free_form_config": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+
+						"extension_values": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+
+						"loopback_id": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+						},
+
+						"loopback_ipv4": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+
+						"loopback_ipv6": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"vrf_lite": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"peer_vrf_name": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"interface_name": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+
+									"dot1q_id": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"ip_mask": {
+										Type:     schema.TypeString,
+										Optional: true,
+										// Computed: true,
+									},
+									"neighbor_ip": {
+										Type:     schema.TypeString,
+										Optional: true,
+
+										// Computed: true,
+									},
+									"neighbor_asn": {
+										Type:     schema.TypeString,
+										Optional: true,
+
+										// Computed: true,
+									},
+									"ipv6_mask": {
+										Type:     schema.TypeString,
+										Optional: true,
+
+										// Computed: true,
+									},
+									"ipv6_neighbor": {
+										Type:     schema.TypeString,
+										Optional: true,
+
+										// Computed: true,
+									},
+									"auto_vrf_lite_flag": {
+										Type:     schema.TypeString,
+										Optional: true,
+
+										// Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// vrfBoolFlags are the flags converted from strings to booleans in version 1.
+var vrfBoolFlags = []string{
+	"trm_enable",
+	"rp_external_flag",
+	"ipv6_link_local_flag",
+	"trm_bgw_msite_flag",
+	"advertise_host_route",
+	"advertise_default_route",
+	"static_default_route",
+}
+
+// resourceDCNMVRFStateUpgradeV0 converts the "true"/"false" flags to booleans.
+// Empty flags are removed, so flags never set stay out of the template config.
+func resourceDCNMVRFStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return nil, nil
+	}
+
+	for _, key := range vrfBoolFlags {
+		value, ok := rawState[key].(string)
+		if !ok {
+			continue
+		}
+		flag, err := strconv.ParseBool(value)
+		if err != nil {
+			delete(rawState, key)
+			continue
+		}
+		rawState[key] = flag
+	}
+
+	return rawState, nil
+}
@@ -0,0 +1,37 @@
+package dcnm
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestResourceDCNMVRFStateUpgradeV0(t *testing.T) {
+	rawState := map[string]interface{}{
+		"name":                    "vrf1",
+		"trm_enable":              "false",
+		"rp_external_flag":        "true",
+		"ipv6_link_local_flag":    "true",
+		"trm_bgw_msite_flag":      "",
+		"advertise_host_route":    "false",
+		"advertise_default_route": "true",
+		"static_default_route":    "false",
+	}
+	expected := map[string]interface{}{
+		"name":                    "vrf1",
+		"trm_enable":              false,
+		"rp_external_flag":        true,
+		"ipv6_link_local_flag":    true,
+		"advertise_host_route":    false,
+		"advertise_default_route": true,
+		"static_default_route":    false,
+	}
+
+	actual, err := resourceDCNMVRFStateUpgradeV0(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatalf("error migrating state: %s", err)
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", expected, actual)
+	}
+}
@@ -2,6 +2,7 @@ package dcnm
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"testing"
 
@@ -14,6 +15,33 @@ import (
 
 var providerfVrf *schema.Provider
 
+func TestSetVRFFlags(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDCNMVRF().Schema, map[string]interface{}{
+		"fabric_name":      "fab2",
+		"name":             "vrf1",
+		"rp_external_flag": false,
+	})
+
+	configMap := models.VRFProfileConfig{}
+	setVRFFlags(d, &configMap)
+
+	if configMap.RPexternal != "false" {
+		t.Errorf("expected the configured rp_external_flag to be sent as false, got %q", configMap.RPexternal)
+	}
+	for name, value := range map[string]string{
+		"trm_enable":           configMap.TRM,
+		"trm_bgw_msite_flag":   configMap.TRMBGW,
+		"advertise_host_route": configMap.AdhostRoute,
+	} {
+		if value != "" {
+			t.Errorf("expected unset %s to be left out, got %q", name, value)
+		}
+	}
+	if configMap.StaticRoute != "true" {
+		t.Errorf("expected static_default_route to default to true, got %q", configMap.StaticRoute)
+	}
+}
+
 func TestAccDCNMVRF_Basic(t *testing.T) {
 	var vrf models.VRF
 	var vrfProfile models.VRFProfileConfig
@@ -61,6 +89,223 @@ func TestAccDCNMVRF_Update(t *testing.T) {
 	})
 }
 
+func TestAccDCNMVRF_Undeploy(t *testing.T) {
+	var vrf models.VRF
+	var vrfProfile models.VRFProfileConfig
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactoriesInternal(&providerfVrf),
+		CheckDestroy:      testAccCheckDCNMVRFDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDCNMVRFConfig_basic("vrf decription check", "true"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMVRFExists("dcnm_vrf.vrf_check", &vrf, &vrfProfile),
+					resource.TestCheckResourceAttr("dcnm_vrf.vrf_check", "deploy", "true"),
+					resource.TestCheckTypeSetElemNestedAttrs("dcnm_vrf.vrf_check", "attachments.*", map[string]string{
+						"serial_number":     "9AYOFL6LTML",
+						"deployment_status": "DEPLOYED",
+						"failure_reason":    "",
+					}),
+				),
+			},
+			{
+				Config: testAccCheckDCNMVRFConfig_basic("vrf decription check", "false"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMVRFExists("dcnm_vrf.vrf_check", &vrf, &vrfProfile),
+					resource.TestCheckResourceAttr("dcnm_vrf.vrf_check", "deploy", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDCNMVRF_DetachSingle(t *testing.T) {
+	var vrf models.VRF
+	var vrfProfile models.VRFProfileConfig
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactoriesInternal(&providerfVrf),
+		CheckDestroy:      testAccCheckDCNMVRFDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDCNMVRFConfig_attachments("9AYOFL6LTML", "9EQ00OGQYV6"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMVRFExists("dcnm_vrf.vrf_check", &vrf, &vrfProfile),
+					resource.TestCheckResourceAttr("dcnm_vrf.vrf_check", "attachments.#", "2"),
+				),
+			},
+			{
+				Config: testAccCheckDCNMVRFConfig_attachments("9AYOFL6LTML"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMVRFExists("dcnm_vrf.vrf_check", &vrf, &vrfProfile),
+					resource.TestCheckResourceAttr("dcnm_vrf.vrf_check", "attachments.#", "1"),
+					testAccCheckDCNMVRFAttachState("dcnm_vrf.vrf_check", "9AYOFL6LTML", "DEPLOYED"),
+					testAccCheckDCNMVRFAttachState("dcnm_vrf.vrf_check", "9EQ00OGQYV6", "NA"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDCNMVRFConfig_attachments(serials ...string) string {
+	attachments := ""
+	for _, serial := range serials {
+		attachments += fmt.Sprintf(`
+		attachments {
+			serial_number = "%s"
+			attach        = true
+		}`, serial)
+	}
+	return fmt.Sprintf(`
+	resource "dcnm_vrf" "vrf_check" {
+		fabric_name = "fab2"
+		name = "two"
+		vlan_id = 2002
+		vlan_name = "check"
+		description = "vrf detach check"
+		deploy = true
+		%s
+	}
+	`, attachments)
+}
+
+func testAccCheckDCNMVRFAttachState(name, serial, status string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("VRF %s not found", name)
+		}
+
+		dcnmClient := (*providerfVrf).Meta().(*client.Client)
+
+		states, err := getVRFAttachStates(dcnmClient, "fab2", rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if state := states[serial]; state.Status != status {
+			return fmt.Errorf("Bad attach state of switch %s: %s", serial, state.Status)
+		}
+		return nil
+	}
+}
+
+func TestAccDCNMVRF_CreateFailurePartial(t *testing.T) {
+	var vrf models.VRF
+	var vrfProfile models.VRFProfileConfig
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactoriesInternal(&providerfVrf),
+		CheckDestroy:      testAccCheckDCNMVRFDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDCNMVRFConfig_createFailure("partial"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMVRFExists("dcnm_vrf.vrf_check", &vrf, &vrfProfile),
+					resource.TestCheckResourceAttr("dcnm_vrf.vrf_check", "deploy", "false"),
+					resource.TestCheckResourceAttr("dcnm_vrf.vrf_check", "attached_switches.#", "0"),
+					// the state keeps the attach flag read back from the switch
+					resource.TestCheckTypeSetElemNestedAttrs("dcnm_vrf.vrf_check", "attachments.*", map[string]string{
+						"serial_number": "9AYOFL6LTMX",
+						"attach":        "false",
+					}),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// the next plan retries the failed deployment
+				Config:             testAccCheckDCNMVRFConfig_createFailure("partial"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccDCNMVRF_CreateFailureRollback(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactoriesInternal(&providerfVrf),
+		CheckDestroy:      testAccCheckDCNMVRFDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckDCNMVRFConfig_createFailure("rollback"),
+				ExpectError: regexp.MustCompile("is rolled back"),
+			},
+		},
+	})
+}
+
+// testAccCheckDCNMVRFConfig_createFailure attaches the VRF to a switch which
+// is not part of the fabric, so its deployment fails.
+func testAccCheckDCNMVRFConfig_createFailure(mode string) string {
+	return fmt.Sprintf(`
+	resource "dcnm_vrf" "vrf_check" {
+		fabric_name       = "fab2"
+		name              = "two"
+		on_create_failure = "%s"
+		deploy            = true
+		attachments {
+			serial_number = "9AYOFL6LTMX"
+			attach        = true
+		}
+	}
+	`, mode)
+}
+
+func TestAccDCNMVRF_Adopt(t *testing.T) {
+	var vrf models.VRF
+	var vrfProfile models.VRFProfileConfig
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactoriesInternal(&providerfVrf),
+		CheckDestroy:      testAccCheckDCNMVRFDestroy,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					testAccCreateUnmanaged(t, providerfVrf, resourceDCNMVRF(), map[string]interface{}{
+						"fabric_name":      "fab2",
+						"name":             "two",
+						"vlan_id":          2002,
+						"vlan_name":        "check",
+						"description":      "vrf created outside terraform",
+						"intf_description": "vrf",
+					})
+				},
+				Config: testAccCheckDCNMVRFConfig_adopt("vrf adopt check"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDCNMVRFExists("dcnm_vrf.vrf_check", &vrf, &vrfProfile),
+					testAccCheckDCNMVRFAttributes("vrf adopt check", &vrf, &vrfProfile),
+					testAccCheckDCNMVRFAttachState("dcnm_vrf.vrf_check", "9AYOFL6LTML", "DEPLOYED"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDCNMVRFConfig_adopt(desc string) string {
+	return fmt.Sprintf(`
+	resource "dcnm_vrf" "vrf_check" {
+		fabric_name      = "fab2"
+		name             = "two"
+		vlan_id          = 2002
+		vlan_name        = "check"
+		description      = "%s"
+		intf_description = "vrf"
+		adopt_existing   = true
+		deploy           = true
+		attachments {
+			serial_number = "9AYOFL6LTML"
+			attach        = true
+		}
+	}
+	`, desc)
+}
+
 func testAccCheckDCNMVRFConfig_basic(desc string, deploy string) string {
 	return fmt.Sprintf(`
 	resource "dcnm_vrf" "vrf_check" {
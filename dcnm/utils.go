@@ -1,14 +1,18 @@
 package dcnm
 
 import (
+	"encoding/json"
 	"fmt"
 	"hash/crc32"
 	"io/ioutil"
+	"net/http"
 	"reflect"
 	"sort"
 	"strings"
 
+	"github.com/ciscoecosystem/dcnm-go-client/client"
 	"github.com/ciscoecosystem/dcnm-go-client/container"
+	"github.com/ciscoecosystem/dcnm-go-client/models"
 )
 
 func stripQuotes(word string) string {
@@ -46,6 +50,15 @@ func cleanJsonString(data string) (*container.Container, error) {
 	return cont, nil
 }
 
+// getTemplateConfig parses a template config embedded as JSON string, unlike
+// cleanJsonString it keeps values which are JSON themselves intact.
+func getTemplateConfig(cont *container.Container, key string) (*container.Container, error) {
+	if config, ok := cont.S(key).Data().(string); ok {
+		return container.ParseJSON([]byte(config))
+	}
+	return cleanJsonString(stripQuotes(cont.S(key).String()))
+}
+
 func listToString(data interface{}) string {
 	values := data.([]interface{})
 
@@ -132,3 +145,320 @@ func readFile(filename string) (string, error) {
 	}
 	return string(data), nil
 }
+
+// makeAndDoRequest sends payload as JSON to a DCNM path. Unlike the model based
+// helpers of the client, payload can be any JSON value such as a list of objects.
+// With raw set the path is not rewritten to the lan-fabric application on ND.
+func makeAndDoRequest(dcnmClient *client.Client, method, path string, payload interface{}, raw bool) (*container.Container, error) {
+	var body *container.Container
+	if payload != nil {
+		payloadBytes, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		body, err = container.ParseJSON(payloadBytes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var req *http.Request
+	var err error
+	if raw && dcnmClient.GetPlatform() == "nd" {
+		req, err = dcnmClient.MakeRestNDRequest(method, path, body, true)
+	} else {
+		req, err = dcnmClient.MakeRequest(method, path, body, true)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cont, resp, err := dcnmClient.Do(req, false)
+	if err != nil {
+		return cont, err
+	}
+	return cont, checkerrorsRest(cont, resp)
+}
+
+// validateTemplateProps rejects template_props overriding the reserved keys
+// of a template config, i.e. the keys set from the attributes of the resource.
+func validateTemplateProps(reserved ...string) func(interface{}, string) ([]string, []error) {
+	return func(i interface{}, k string) ([]string, []error) {
+		var errs []error
+		for key := range i.(map[string]interface{}) {
+			for _, name := range reserved {
+				if key == name {
+					errs = append(errs, fmt.Errorf("%s: %s is set by the provider and can not be overridden, use its dedicated attribute", k, key))
+				}
+			}
+		}
+		return nil, errs
+	}
+}
+
+// templateConfigKeys returns the template config keys set from a profile
+// config struct, i.e. the JSON names of its fields.
+func templateConfigKeys(profile interface{}) []string {
+	profileType := reflect.TypeOf(profile)
+	keys := make([]string, 0, profileType.NumField())
+	for i := 0; i < profileType.NumField(); i++ {
+		if name := strings.Split(profileType.Field(i).Tag.Get("json"), ",")[0]; name != "" && name != "-" {
+			keys = append(keys, name)
+		}
+	}
+	return keys
+}
+
+// mergeTemplateProps merges template_props into the JSON template config
+// generated from the attributes. The props can not contain keys set from the
+// attributes, see validateTemplateProps.
+func mergeTemplateProps(config []byte, props map[string]interface{}) (string, error) {
+	configMap := make(map[string]interface{})
+	if err := json.Unmarshal(config, &configMap); err != nil {
+		return "", err
+	}
+	for key, value := range props {
+		configMap[key] = value.(string)
+	}
+
+	configStr, err := json.Marshal(configMap)
+	if err != nil {
+		return "", err
+	}
+	return string(configStr), nil
+}
+
+// readTemplateProps returns the values of the keys of props in the template
+// config, keys missing in the config are left out so they show up as a diff.
+func readTemplateProps(config *container.Container, props map[string]interface{}) map[string]interface{} {
+	remote := make(map[string]interface{})
+	for key := range props {
+		value := config.S(key)
+		if value == nil {
+			continue
+		}
+		if str, ok := value.Data().(string); ok {
+			remote[key] = str
+		} else {
+			remote[key] = value.String()
+		}
+	}
+	return remote
+}
+
+// settingValue returns the string form of a setting, JSON for anything but
+// strings and an empty string for a missing value.
+func settingValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(valueBytes)
+}
+
+// settingsChanges returns the keys of desired whose value differs from the
+// remote settings, in sorted order. Values are compared by their string form
+// so "10" and 10 match, a missing remote value matches an empty one.
+func settingsChanges(desired map[string]interface{}, remote *container.Container) []string {
+	changes := make([]string, 0)
+	for key, value := range desired {
+		if settingValue(value) != settingValue(remote.S(key).Data()) {
+			changes = append(changes, key)
+		}
+	}
+	sort.Strings(changes)
+	return changes
+}
+
+// attachmentsMatch returns whether every attachment is attached or detached
+// as configured, with the attached switches deployed.
+func attachmentsMatch(attachments []interface{}, states map[string]attachmentState) bool {
+	for _, val := range attachments {
+		attachment := val.(map[string]interface{})
+		state, ok := states[attachment["serial_number"].(string)]
+		attach := attachment["attach"].(bool)
+		if !ok || state.Attached != attach || (attach && state.Status != "DEPLOYED") {
+			return false
+		}
+	}
+	return true
+}
+
+// attachmentState is the deployment state of a VRF or network attachment as
+// reported by the lan attach list of the switch.
+type attachmentState struct {
+	SwitchName    string
+	Status        string
+	FailureReason string
+	Attached      bool
+}
+
+// parseAttachmentStates returns the attachment states of a lan attach list
+// keyed by the serial number of the switch.
+func parseAttachmentStates(attachList []*container.Container) map[string]attachmentState {
+	states := make(map[string]attachmentState)
+	for _, attachCont := range attachList {
+		state := attachmentState{}
+		state.SwitchName, _ = attachCont.S("switchName").Data().(string)
+		state.Status, _ = attachCont.S("lanAttachState").Data().(string)
+		state.Attached, _ = attachCont.S("isLanAttached").Data().(bool)
+		if state.Status == "FAILED" {
+			state.FailureReason, _ = attachCont.S("errorMessage").Data().(string)
+		}
+
+		serial, _ := attachCont.S("switchSerialNo").Data().(string)
+		states[serial] = state
+	}
+	return states
+}
+
+// attachedSwitches returns the serial numbers of the attached switches in
+// sorted order.
+func attachedSwitches(states map[string]attachmentState) []string {
+	serials := make([]string, 0, len(states))
+	for serial, state := range states {
+		if state.Attached {
+			serials = append(serials, serial)
+		}
+	}
+	sort.Strings(serials)
+	return serials
+}
+
+// attachmentsDeployed returns whether at least one switch is attached and
+// all the attached switches are deployed.
+func attachmentsDeployed(states map[string]attachmentState) bool {
+	attached := false
+	for _, state := range states {
+		if !state.Attached {
+			continue
+		}
+		if state.Status != "DEPLOYED" {
+			return false
+		}
+		attached = true
+	}
+	return attached
+}
+
+// resourceReservation is an ID taken from a resource manager pool of a
+// fabric, e.g. the VLAN allocated to a new VRF. With Entity set, only
+// resources whose entity name contains it match, e.g. the serial number of
+// the switch holding a dot1q ID.
+type resourceReservation struct {
+	Pool   string
+	Value  string
+	Entity string
+}
+
+// matchReservations returns the IDs of the resources of a pool holding one
+// of the reservations.
+func matchReservations(resources []*container.Container, reservations []resourceReservation) []string {
+	ids := make([]string, 0)
+	for _, resource := range resources {
+		pool := models.G(resource.S("resourcePool"), "poolName")
+		value := models.G(resource, "allocatedIp")
+		entity := models.G(resource, "entityName")
+		for _, reservation := range reservations {
+			if reservation.Pool == pool && reservation.Value == value && strings.Contains(entity, reservation.Entity) {
+				ids = append(ids, models.G(resource, "id"))
+				break
+			}
+		}
+	}
+	return ids
+}
+
+// unattachedReservations returns the reservations held for switches which
+// are not attached, e.g. after a failed attachment request.
+func unattachedReservations(reservations []resourceReservation, states map[string]attachmentState) []resourceReservation {
+	unattached := make([]resourceReservation, 0, len(reservations))
+	for _, reservation := range reservations {
+		if !states[reservation.Entity].Attached {
+			unattached = append(unattached, reservation)
+		}
+	}
+	return unattached
+}
+
+// releaseReservations releases reservations of a fabric from the resource
+// manager. Reservations already released along with their object are skipped.
+func releaseReservations(dcnmClient *client.Client, fabricName string, reservations []resourceReservation) error {
+	pools, seen := make([]string, 0), make(map[string]bool)
+	for _, reservation := range reservations {
+		if !seen[reservation.Pool] {
+			pools = append(pools, reservation.Pool)
+			seen[reservation.Pool] = true
+		}
+	}
+
+	ids := make([]string, 0)
+	for _, pool := range pools {
+		durl := fmt.Sprintf("/rest/resource-manager/fabric/%s/pools/%s", fabricName, pool)
+		cont, err := makeAndDoRequest(dcnmClient, "GET", durl, nil, false)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, matchReservations(cont.Children(), reservations)...)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := makeAndDoRequest(dcnmClient, "DELETE", "/rest/resource-manager/resources?id="+strings.Join(ids, ","), nil, false)
+	return err
+}
+
+// setAttachmentState sets the deployment_status and failure_reason of an
+// attachment block.
+func setAttachmentState(attachMap map[string]interface{}, state attachmentState) {
+	attachMap["deployment_status"] = state.Status
+	attachMap["failure_reason"] = state.FailureReason
+}
+
+// staleFailures returns the serial numbers of the switches whose deployment
+// has already failed before a deploy request.
+func staleFailures(states map[string]attachmentState) map[string]bool {
+	stale := make(map[string]bool)
+	for serial, state := range states {
+		if state.Status == "FAILED" {
+			stale[serial] = true
+		}
+	}
+	return stale
+}
+
+// attachmentFailure returns an error for the first switch, in serial number
+// order, whose deployment has failed. A FAILED state of a switch in stale is
+// left over from an earlier deployment and skipped, until the switch reports
+// another state and is removed from stale.
+func attachmentFailure(states map[string]attachmentState, stale map[string]bool) error {
+	serials := make([]string, 0, len(states))
+	for serial := range states {
+		serials = append(serials, serial)
+	}
+	sort.Strings(serials)
+
+	for _, serial := range serials {
+		state := states[serial]
+		if state.Status != "FAILED" {
+			delete(stale, serial)
+			continue
+		}
+		if stale[serial] {
+			continue
+		}
+		reason := state.FailureReason
+		if reason == "" {
+			reason = "no failure reason reported"
+		}
+		return fmt.Errorf("deployment failed on switch %s (%s): %s", state.SwitchName, serial, reason)
+	}
+	return nil
+}
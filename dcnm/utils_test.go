@@ -0,0 +1,295 @@
+package dcnm
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ciscoecosystem/dcnm-go-client/container"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAttachmentFailure(t *testing.T) {
+	cases := []struct {
+		name     string
+		attach   string
+		expected string
+	}{
+		{
+			name:     "deployed",
+			attach:   `[{"switchSerialNo":"9A1","switchName":"leaf1","lanAttachState":"DEPLOYED"},{"switchSerialNo":"9A2","switchName":"leaf2","lanAttachState":"NA"}]`,
+			expected: "",
+		},
+		{
+			name:     "pending",
+			attach:   `[{"switchSerialNo":"9A1","switchName":"leaf1","lanAttachState":"PENDING"}]`,
+			expected: "",
+		},
+		{
+			name:     "failed",
+			attach:   `[{"switchSerialNo":"9A2","switchName":"leaf2","lanAttachState":"FAILED","errorMessage":"Invalid command"},{"switchSerialNo":"9A1","switchName":"leaf1","lanAttachState":"FAILED"}]`,
+			expected: "deployment failed on switch leaf1 (9A1): no failure reason reported",
+		},
+		{
+			name:     "failed with reason",
+			attach:   `[{"switchSerialNo":"9A1","switchName":"leaf1","lanAttachState":"DEPLOYED"},{"switchSerialNo":"9A2","switchName":"leaf2","lanAttachState":"FAILED","errorMessage":"Invalid command"}]`,
+			expected: "deployment failed on switch leaf2 (9A2): Invalid command",
+		},
+	}
+
+	for _, c := range cases {
+		cont, err := container.ParseJSON([]byte(c.attach))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = attachmentFailure(parseAttachmentStates(cont.Children()), map[string]bool{})
+		actual := ""
+		if err != nil {
+			actual = err.Error()
+		}
+		if actual != c.expected {
+			t.Errorf("%s: expected %q, got %q", c.name, c.expected, actual)
+		}
+	}
+}
+
+func TestAttachmentFailureStale(t *testing.T) {
+	polls := []struct {
+		attach string
+		failed bool
+	}{
+		// failure of an earlier deployment, before the deploy request
+		{`[{"switchSerialNo":"9A1","switchName":"leaf1","lanAttachState":"FAILED","errorMessage":"Invalid command"}]`, false},
+		// first poll still showing the earlier failure
+		{`[{"switchSerialNo":"9A1","switchName":"leaf1","lanAttachState":"FAILED","errorMessage":"Invalid command"}]`, false},
+		{`[{"switchSerialNo":"9A1","switchName":"leaf1","lanAttachState":"PENDING"}]`, false},
+		{`[{"switchSerialNo":"9A1","switchName":"leaf1","lanAttachState":"FAILED","errorMessage":"Invalid command"}]`, true},
+	}
+
+	var stale map[string]bool
+	for i, poll := range polls {
+		cont, err := container.ParseJSON([]byte(poll.attach))
+		if err != nil {
+			t.Fatal(err)
+		}
+		states := parseAttachmentStates(cont.Children())
+		if i == 0 {
+			stale = staleFailures(states)
+			continue
+		}
+		if err := attachmentFailure(states, stale); (err != nil) != poll.failed {
+			t.Errorf("poll %d: expected failure %t, got %v", i, poll.failed, err)
+		}
+	}
+}
+
+func TestSetAttachmentState(t *testing.T) {
+	cont, err := container.ParseJSON([]byte(`[{"switchSerialNo":"9A1","switchName":"leaf1","lanAttachState":"DEPLOYED"},{"switchSerialNo":"9A2","switchName":"leaf2","lanAttachState":"FAILED","errorMessage":"Invalid command"}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	states := parseAttachmentStates(cont.Children())
+
+	resources := map[string]*schema.Resource{
+		"dcnm_vrf":     resourceDCNMVRF(),
+		"dcnm_network": resourceDCNMNetwork(),
+	}
+	for name, resource := range resources {
+		d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+			"fabric_name": "fab2",
+			"name":        "check",
+			"attachments": []interface{}{
+				map[string]interface{}{"serial_number": "9A1", "attach": true},
+				map[string]interface{}{"serial_number": "9A2", "attach": true},
+			},
+		})
+
+		attachments := d.Get("attachments").(*schema.Set).List()
+		for _, val := range attachments {
+			attachMap := val.(map[string]interface{})
+			setAttachmentState(attachMap, states[attachMap["serial_number"].(string)])
+		}
+		if err := d.Set("attachments", attachments); err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+
+		expected := map[string][2]string{
+			"9A1": {"DEPLOYED", ""},
+			"9A2": {"FAILED", "Invalid command"},
+		}
+		for _, val := range d.Get("attachments").(*schema.Set).List() {
+			attachMap := val.(map[string]interface{})
+			serial := attachMap["serial_number"].(string)
+			actual := [2]string{attachMap["deployment_status"].(string), attachMap["failure_reason"].(string)}
+			if actual != expected[serial] {
+				t.Errorf("%s: switch %s: expected %v, got %v", name, serial, expected[serial], actual)
+			}
+		}
+	}
+}
+
+func TestMatchReservations(t *testing.T) {
+	cont, err := container.ParseJSON([]byte(`[
+		{"id":11,"resourcePool":{"poolName":"TOP_DOWN_VRF_VLAN"},"entityName":"two","allocatedIp":"2002"},
+		{"id":12,"resourcePool":{"poolName":"TOP_DOWN_VRF_VLAN"},"entityName":"one","allocatedIp":"2001"},
+		{"id":13,"resourcePool":{"poolName":"L3_VNI"},"entityName":"two","allocatedIp":"50002"},
+		{"id":14,"resourcePool":{"poolName":"TOP_DOWN_L3_DOT1Q"},"entityName":"9A1~Ethernet1/1","allocatedIp":"2"},
+		{"id":15,"resourcePool":{"poolName":"TOP_DOWN_L3_DOT1Q"},"entityName":"9A2~Ethernet1/1","allocatedIp":"2"}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := matchReservations(cont.Children(), []resourceReservation{
+		{Pool: "TOP_DOWN_VRF_VLAN", Value: "2002"},
+		{Pool: "L3_VNI", Value: "50002"},
+		{Pool: "TOP_DOWN_L3_DOT1Q", Value: "2", Entity: "9A2"},
+		{Pool: "L3_VNI", Value: "2002"},
+	})
+	if expected := []string{"11", "13", "15"}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("expected %v, got %v", expected, ids)
+	}
+}
+
+func TestUnattachedReservations(t *testing.T) {
+	reservations := []resourceReservation{
+		{Pool: "TOP_DOWN_L3_DOT1Q", Value: "2", Entity: "9A1"},
+		{Pool: "TOP_DOWN_L3_DOT1Q", Value: "3", Entity: "9A2"},
+		{Pool: "TOP_DOWN_L3_DOT1Q", Value: "4", Entity: "9A3"},
+	}
+	states := map[string]attachmentState{
+		"9A1": {Attached: true, Status: "PENDING"},
+		"9A2": {Attached: false, Status: "NA"},
+	}
+
+	expected := []resourceReservation{reservations[1], reservations[2]}
+	if unattached := unattachedReservations(reservations, states); !reflect.DeepEqual(unattached, expected) {
+		t.Errorf("expected %v, got %v", expected, unattached)
+	}
+}
+
+func TestAttachmentsDeployed(t *testing.T) {
+	cases := []struct {
+		states   map[string]attachmentState
+		expected bool
+	}{
+		{map[string]attachmentState{"9A1": {Attached: true, Status: "DEPLOYED"}, "9A2": {Attached: false, Status: "NA"}}, true},
+		// a partial deployment, only the first attachment is deployed
+		{map[string]attachmentState{"9A1": {Attached: true, Status: "DEPLOYED"}, "9A2": {Attached: true, Status: "FAILED"}}, false},
+		{map[string]attachmentState{"9A1": {Attached: true, Status: "PENDING"}}, false},
+		{map[string]attachmentState{"9A1": {Attached: false, Status: "NA"}}, false},
+		{map[string]attachmentState{}, false},
+	}
+
+	for i, c := range cases {
+		if deployed := attachmentsDeployed(c.states); deployed != c.expected {
+			t.Errorf("case %d: expected %t, got %t", i, c.expected, deployed)
+		}
+	}
+}
+
+func TestAttachedSwitches(t *testing.T) {
+	states := map[string]attachmentState{
+		"9A2": {Attached: true},
+		"9A1": {Attached: false},
+		"9A3": {Attached: true},
+	}
+	if switches, expected := attachedSwitches(states), []string{"9A2", "9A3"}; !reflect.DeepEqual(switches, expected) {
+		t.Errorf("expected attached switches %v, got %v", expected, switches)
+	}
+}
+
+func TestSettingsChanges(t *testing.T) {
+	remote, err := container.ParseJSON([]byte(`{"vrfName":"check","vrfVlanId":"2000","mtu":9216,"trmEnabled":"false","tag":""}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		desired  map[string]interface{}
+		expected []string
+	}{
+		{map[string]interface{}{"vrfName": "check", "vrfVlanId": 2000, "mtu": "9216"}, []string{}},
+		// settings missing remotely match empty ones
+		{map[string]interface{}{"tag": "", "vrfDescription": ""}, []string{}},
+		{map[string]interface{}{"vrfVlanId": "2001", "trmEnabled": "true", "vrfDescription": "web"}, []string{"trmEnabled", "vrfDescription", "vrfVlanId"}},
+	}
+
+	for _, c := range cases {
+		changes := settingsChanges(c.desired, remote)
+		if !reflect.DeepEqual(changes, c.expected) {
+			t.Errorf("%v: expected %v, got %v", c.desired, c.expected, changes)
+		}
+	}
+}
+
+func TestAttachmentsMatch(t *testing.T) {
+	states := map[string]attachmentState{
+		"9A1": {Status: "DEPLOYED", Attached: true},
+		"9A2": {Status: "PENDING", Attached: true},
+		"9A3": {Status: "NA", Attached: false},
+	}
+
+	cases := []struct {
+		attachments []interface{}
+		expected    bool
+	}{
+		{[]interface{}{map[string]interface{}{"serial_number": "9A1", "attach": true}, map[string]interface{}{"serial_number": "9A3", "attach": false}}, true},
+		// attached but not deployed yet
+		{[]interface{}{map[string]interface{}{"serial_number": "9A2", "attach": true}}, false},
+		{[]interface{}{map[string]interface{}{"serial_number": "9A3", "attach": true}}, false},
+		{[]interface{}{map[string]interface{}{"serial_number": "9A1", "attach": false}}, false},
+		{[]interface{}{map[string]interface{}{"serial_number": "9A4", "attach": true}}, false},
+	}
+
+	for i, c := range cases {
+		if matched := attachmentsMatch(c.attachments, states); matched != c.expected {
+			t.Errorf("case %d: expected %t, got %t", i, c.expected, matched)
+		}
+	}
+}
+
+func TestValidateTemplateProps(t *testing.T) {
+	validate := resourceDCNMVRF().Schema["template_props"].ValidateFunc
+
+	cases := []struct {
+		props  map[string]interface{}
+		failed bool
+	}{
+		{map[string]interface{}{"vrfRouteMap": "FABRIC-RMAP-REDIST-SUBNET", "disableRtAuto": "true"}, false},
+		// set from vlan_id and the name
+		{map[string]interface{}{"vrfVlanId": "500"}, true},
+		{map[string]interface{}{"vrfName": "other"}, true},
+	}
+
+	for _, c := range cases {
+		if _, errs := validate(c.props, "template_props"); (len(errs) > 0) != c.failed {
+			t.Errorf("%v: expected failure %t, got %v", c.props, c.failed, errs)
+		}
+	}
+
+	networkValidate := resourceDCNMNetwork().Schema["template_props"].ValidateFunc
+	if _, errs := networkValidate(map[string]interface{}{"dhcpServers": "{}", "vlanId": "500"}, "template_props"); len(errs) != 2 {
+		t.Errorf("expected dhcpServers and vlanId to be rejected, got %v", errs)
+	}
+}
+
+func TestGetTemplateConfigEscaped(t *testing.T) {
+	config, err := mergeTemplateProps([]byte(`{"vrfName":"check"}`), map[string]interface{}{"routeMaps": `{"in":"RM \"A\""}`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cont, err := container.ParseJSON([]byte(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cont.Set(config, "vrfTemplateConfig")
+
+	configCont, err := getTemplateConfig(cont, "vrfTemplateConfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	props := readTemplateProps(configCont, map[string]interface{}{"routeMaps": ""})
+	if expected := `{"in":"RM \"A\""}`; props["routeMaps"] != expected {
+		t.Errorf("expected %s, got %v", expected, props["routeMaps"])
+	}
+}
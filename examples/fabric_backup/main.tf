@@ -0,0 +1,28 @@
+terraform {
+  required_providers {
+    dcnm = {
+      source = "CiscoDevNet/dcnm"
+    }
+  }
+}
+
+provider "dcnm" {
+  username = ""
+  password = ""
+  url      = ""
+  # expiry   = 900000
+}
+
+resource "dcnm_fabric_backup" "first" {
+  fabric_name = "fab2"
+  tag         = ""
+
+  triggers = {
+    change = ""
+  }
+}
+
+data "dcnm_fabric_backups" "first" {
+  fabric_name   = "fab2"
+  serial_number = ""
+}
@@ -0,0 +1,27 @@
+terraform {
+  required_providers {
+    dcnm = {
+      source = "CiscoDevNet/dcnm"
+    }
+  }
+}
+
+provider "dcnm" {
+  username = ""
+  password = ""
+  url      = ""
+  # expiry   = 900000
+}
+
+resource "dcnm_image_policy" "first" {
+  name         = "n9k_policy"
+  platform     = "N9K"
+  nxos_version = ""
+  description  = ""
+}
+
+resource "dcnm_image_policy_attachment" "first" {
+  fabric_name    = "fab2"
+  policy_name    = dcnm_image_policy.first.name
+  serial_numbers = [""]
+}
@@ -0,0 +1,40 @@
+terraform {
+  required_providers {
+    dcnm = {
+      source = "CiscoDevNet/dcnm"
+    }
+  }
+}
+
+provider "dcnm" {
+  username = ""
+  password = ""
+  url      = ""
+  # expiry   = 900000
+}
+
+resource "dcnm_inventory_poap" "first" {
+  fabric_name    = "fab2"
+  password       = ""
+  config_timeout = 20
+
+  switch_config {
+    serial_number = ""
+    hostname      = ""
+    ip            = ""
+    gateway       = ""
+    role          = "leaf"
+  }
+
+  switch_config {
+    serial_number = ""
+    hostname      = ""
+    ip            = ""
+    gateway       = ""
+    model         = ""
+    version       = ""
+    modules_model = [""]
+    role          = "leaf"
+    preprovision  = true
+  }
+}
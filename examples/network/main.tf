@@ -25,20 +25,26 @@ resource "dcnm_network" "first" {
   ipv4_gateway    = "192.0.3.1/24"
   ipv6_gateway    = "2001:db8::1/64"
   mtu             = 1500
-  secondary_gw_1  = "192.0.3.1/24"
-  secondary_gw_2  = "192.0.3.1/24"
+  secondary_gateways = ["192.0.4.1/24"]
   arp_supp_flag   = true
   ir_enable_flag  = false
   mcast_group     = "239.1.2.2"
-  dhcp_1          = "1.2.3.4"
-  dhcp_2          = "1.2.3.5"
-  dhcp_vrf        = "VRF1012"
   loopback_id     = 100
   tag             = "1400"
   rt_both_flag    = true
   trm_enable_flag = true
   l3_gateway_flag = true
 
+  dhcp_relay {
+    server_address = "1.2.3.4"
+    vrf_name       = "VRF1012"
+  }
+
+  dhcp_relay {
+    server_address = "1.2.3.5"
+    vrf_name       = "VRF1012"
+  }
+
   deploy = true
   attachments {
     serial_number = dcnm_inventory.example1.serial_number
@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    dcnm = {
+      source = "CiscoDevNet/dcnm"
+    }
+  }
+}
+
+provider "dcnm" {
+  username = ""
+  password = ""
+  url      = ""
+  # expiry   = 900000
+}
+
+resource "dcnm_switch" "first" {
+  fabric_name   = "fab2"
+  serial_number = ""
+  role          = "leaf"
+  mode          = "normal"
+  freeze        = false
+}
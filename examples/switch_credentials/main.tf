@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    dcnm = {
+      source = "CiscoDevNet/dcnm"
+    }
+  }
+}
+
+provider "dcnm" {
+  username = ""
+  password = ""
+  url      = ""
+  # expiry   = 900000
+}
+
+resource "dcnm_switch_credentials" "first" {
+  fabric_name   = "fab2"
+  serial_number = ""
+  username      = ""
+  password      = ""
+  auth_protocol = 0
+}
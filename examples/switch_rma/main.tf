@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    dcnm = {
+      source = "CiscoDevNet/dcnm"
+    }
+  }
+}
+
+provider "dcnm" {
+  username = ""
+  password = ""
+  url      = ""
+  # expiry   = 900000
+}
+
+resource "dcnm_switch_rma" "first" {
+  fabric_name       = "fab2"
+  old_serial_number = ""
+  new_serial_number = ""
+  password          = ""
+  gateway           = ""
+}
@@ -0,0 +1,20 @@
+terraform {
+  required_providers {
+    dcnm = {
+      source = "CiscoDevNet/dcnm"
+    }
+  }
+}
+
+provider "dcnm" {
+  username = ""
+  password = ""
+  url      = ""
+  # expiry   = 900000
+}
+
+resource "dcnm_switch_upgrade" "first" {
+  fabric_name    = "fab2"
+  serial_numbers = [""]
+  upgrade_mode   = "disruptive"
+}
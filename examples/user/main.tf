@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    dcnm = {
+      source = "CiscoDevNet/dcnm"
+    }
+  }
+}
+
+provider "dcnm" {
+  username = ""
+  password = ""
+  url      = ""
+  # expiry   = 900000
+}
+
+resource "dcnm_user" "first" {
+  name       = "operator"
+  password   = ""
+  first_name = ""
+  last_name  = ""
+}
+
+resource "dcnm_user_role" "first" {
+  user_name = dcnm_user.first.name
+  roles     = ["network-operator"]
+}
@@ -34,10 +34,10 @@ resource "dcnm_vrf" "first" {
   loopback_id             = 15
   mutlicast_address       = "10.0.0.2"
   mutlicast_group         = "224.0.0.1/4"
-  ipv6_link_local_flag    = "true"
+  ipv6_link_local_flag    = true
   trm_bgw_msite_flag      = false
   advertise_host_route    = false
-  advertise_default_route = "true"
+  advertise_default_route = true
   static_default_route    = false
   deploy                  = true
   attachments {